@@ -0,0 +1,127 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/streadway/amqp"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeEventConsumer is a stand-in for *amqp.Channel that hands consumeEvents
+// a deliveries channel the test controls directly, instead of needing a
+// real RabbitMQ broker.
+type fakeEventConsumer struct {
+	deliveries chan amqp.Delivery
+}
+
+func (f *fakeEventConsumer) QueueDeclare(name string, durable, autoDelete, exclusive, noWait bool, args amqp.Table) (amqp.Queue, error) {
+	return amqp.Queue{Name: "test-queue"}, nil
+}
+
+func (f *fakeEventConsumer) QueueBind(name, key, exchange string, noWait bool, args amqp.Table) error {
+	return nil
+}
+
+func (f *fakeEventConsumer) Consume(queue, consumer string, autoAck, exclusive, noLocal, noWait bool, args amqp.Table) (<-chan amqp.Delivery, error) {
+	return f.deliveries, nil
+}
+
+func newTestClient(userID uuid.UUID) *client {
+	return &client{userID: userID, send: make(chan []byte, 2), done: make(chan struct{})}
+}
+
+func TestRegisterUnregister(t *testing.T) {
+	ns := NewNotificationService(nil, &fakeEventConsumer{}, nil)
+	c := newTestClient(uuid.New())
+
+	ns.register(c)
+	ns.mu.RLock()
+	_, registered := ns.clients[c]
+	ns.mu.RUnlock()
+	require.True(t, registered)
+
+	ns.unregister(c)
+	ns.mu.RLock()
+	_, registered = ns.clients[c]
+	ns.mu.RUnlock()
+	require.False(t, registered)
+
+	select {
+	case <-c.done:
+	default:
+		t.Fatal("unregister should have closed c.done")
+	}
+
+	// readPump's deferred cleanup always calls unregister, even if the
+	// client was already removed some other way, so a second call must be
+	// a safe no-op rather than a double-close panic.
+	require.NotPanics(t, func() { ns.unregister(c) })
+}
+
+// TestConsumeEvents_StopsAfterUnregister is a regression test for a crash
+// where consumeEvents kept running after a client disconnected: the old
+// code closed c.send on unregister, and the very next matching event for
+// that user would panic trying to send on a closed channel. It now signals
+// consumeEvents via c.done instead, and never closes send.
+func TestConsumeEvents_StopsAfterUnregister(t *testing.T) {
+	userID := uuid.New()
+	c := newTestClient(userID)
+
+	ns := NewNotificationService(nil, &fakeEventConsumer{deliveries: make(chan amqp.Delivery)}, nil)
+	ns.register(c)
+
+	stopped := make(chan struct{})
+	go func() {
+		ns.consumeEvents(c)
+		close(stopped)
+	}()
+
+	ns.unregister(c)
+
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Fatal("consumeEvents did not return after the client was unregistered")
+	}
+
+	// A send racing in right as done fires must not panic — send is never
+	// closed, only done is.
+	require.NotPanics(t, func() {
+		select {
+		case c.send <- []byte("late event"):
+		default:
+		}
+	})
+}
+
+func TestConsumeEvents_ForwardsOnlyMatchingOwner(t *testing.T) {
+	userID := uuid.New()
+	otherUserID := uuid.New()
+	c := newTestClient(userID)
+
+	deliveries := make(chan amqp.Delivery, 2)
+	ns := NewNotificationService(nil, &fakeEventConsumer{deliveries: deliveries}, nil)
+
+	notMine, err := json.Marshal(event{Type: "post.created", OwnerID: otherUserID})
+	require.NoError(t, err)
+	mine, err := json.Marshal(event{Type: "post.created", OwnerID: userID})
+	require.NoError(t, err)
+
+	deliveries <- amqp.Delivery{Body: notMine}
+	deliveries <- amqp.Delivery{Body: mine}
+
+	go ns.consumeEvents(c)
+	defer ns.unregister(c)
+
+	select {
+	case body := <-c.send:
+		var evt event
+		require.NoError(t, json.Unmarshal(body, &evt))
+		require.Equal(t, userID, evt.OwnerID)
+	case <-time.After(time.Second):
+		t.Fatal("expected the event owned by this user to be forwarded")
+	}
+}
@@ -0,0 +1,122 @@
+package activitypub
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+	"time"
+)
+
+const (
+	deliveryQueueSize  = 256
+	maxDeliveryRetries = 5
+	retryBaseDelay     = time.Second * 2
+)
+
+// delivery is a single signed activity headed to a single remote inbox.
+type delivery struct {
+	inboxURL      string
+	actorID       string
+	keyID         string
+	privateKeyPEM string
+	activity      any
+	attempt       int
+}
+
+// DeliveryWorker pulls signed activities off a channel and POSTs them to
+// remote inboxes, retrying transient failures with backoff so a slow or
+// unreachable remote server never blocks the HTTP handler that enqueued it.
+type DeliveryWorker struct {
+	client *http.Client
+	queue  chan delivery
+}
+
+func NewDeliveryWorker() *DeliveryWorker {
+	return &DeliveryWorker{
+		client: &http.Client{Timeout: time.Second * 10},
+		queue:  make(chan delivery, deliveryQueueSize),
+	}
+}
+
+// Run drains the delivery queue until ctx is cancelled. It's meant to be
+// started once from cmd/main.go in its own goroutine.
+func (w *DeliveryWorker) Run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case d := <-w.queue:
+			w.deliver(ctx, d)
+		}
+	}
+}
+
+// Enqueue schedules an activity for delivery to inboxURL, signed as actorID
+// using its keypair. Non-blocking for callers as long as the queue has room.
+func (w *DeliveryWorker) Enqueue(actorID, keyID, privateKeyPEM, inboxURL string, activity any) {
+	w.queue <- delivery{
+		inboxURL:      inboxURL,
+		actorID:       actorID,
+		keyID:         keyID,
+		privateKeyPEM: privateKeyPEM,
+		activity:      activity,
+	}
+}
+
+func (w *DeliveryWorker) deliver(ctx context.Context, d delivery) {
+	body, err := json.Marshal(d.activity)
+	if err != nil {
+		log.Printf("activitypub: failed to marshal activity for %s: %v", d.inboxURL, err)
+		return
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, d.inboxURL, bytes.NewReader(body))
+	if err != nil {
+		log.Printf("activitypub: failed to build request for %s: %v", d.inboxURL, err)
+		return
+	}
+	req.Header.Set("Content-Type", "application/activity+json")
+	req.Header.Set("Date", time.Now().UTC().Format(http.TimeFormat))
+
+	if err := signRequest(req, d.keyID, d.privateKeyPEM, body); err != nil {
+		log.Printf("activitypub: failed to sign delivery to %s: %v", d.inboxURL, err)
+		return
+	}
+
+	resp, err := w.client.Do(req)
+	if err == nil {
+		defer resp.Body.Close()
+		if resp.StatusCode < 300 {
+			return
+		}
+		err = errStatus(resp.StatusCode)
+	}
+
+	d.attempt++
+	if d.attempt >= maxDeliveryRetries {
+		log.Printf("activitypub: giving up delivering to %s after %d attempts: %v", d.inboxURL, d.attempt, err)
+		return
+	}
+
+	delay := retryBaseDelay * time.Duration(1<<d.attempt)
+	log.Printf("activitypub: delivery to %s failed (attempt %d): %v, retrying in %s", d.inboxURL, d.attempt, err, delay)
+
+	go func() {
+		select {
+		case <-ctx.Done():
+		case <-time.After(delay):
+			select {
+			case w.queue <- d:
+			case <-ctx.Done():
+			}
+		}
+	}()
+}
+
+type errStatus int
+
+func (e errStatus) Error() string {
+	return http.StatusText(int(e))
+}
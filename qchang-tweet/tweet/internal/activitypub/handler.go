@@ -0,0 +1,103 @@
+package activitypub
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"tweet.io/internal/platform/response"
+)
+
+type handler struct {
+	service *Service
+	fetcher remoteActorFetcher
+}
+
+// remoteActorFetcher resolves a remote actor IRI to its public key PEM so
+// inbound deliveries can be verified; split out so tests can fake it.
+type remoteActorFetcher interface {
+	FetchPublicKey(actorIRI string) (string, error)
+}
+
+func NewHandler(service *Service, fetcher remoteActorFetcher) *handler {
+	return &handler{service: service, fetcher: fetcher}
+}
+
+func (h *handler) WebFinger(w http.ResponseWriter, r *http.Request) {
+	resource := r.URL.Query().Get("resource")
+	if resource == "" {
+		response.Error(w, http.StatusBadRequest, ErrInvalidResource)
+		return
+	}
+
+	resp, err := h.service.WebFinger(r.Context(), resource)
+	if err != nil {
+		response.Error(w, http.StatusNotFound, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/jrd+json")
+	response.Success(w, http.StatusOK, resp)
+}
+
+func (h *handler) Actor(w http.ResponseWriter, r *http.Request) {
+	displayname := mux.Vars(r)["displayname"]
+
+	actor, err := h.service.GetActor(r.Context(), displayname)
+	if err != nil {
+		response.Error(w, http.StatusNotFound, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/activity+json")
+	response.Success(w, http.StatusOK, actor)
+}
+
+func (h *handler) Outbox(w http.ResponseWriter, r *http.Request) {
+	displayname := mux.Vars(r)["displayname"]
+
+	outbox, err := h.service.GetOutbox(r.Context(), displayname)
+	if err != nil {
+		response.Error(w, http.StatusNotFound, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/activity+json")
+	response.Success(w, http.StatusOK, outbox)
+}
+
+func (h *handler) Inbox(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, err)
+		return
+	}
+	defer r.Body.Close()
+
+	var activity Create
+	if err := json.Unmarshal(body, &activity); err != nil {
+		response.Error(w, http.StatusBadRequest, err)
+		return
+	}
+
+	err = h.service.HandleInbox(r.Context(), activity, func() error {
+		publicKeyPEM, err := h.fetcher.FetchPublicKey(activity.Actor)
+		if err != nil {
+			return err
+		}
+
+		return verifyRequest(r, body, publicKeyPEM)
+	})
+	if err != nil {
+		switch err {
+		case ErrMissingSignature, ErrInvalidSignature, ErrInvalidDigest:
+			response.Error(w, http.StatusUnauthorized, err)
+		default:
+			response.Error(w, http.StatusBadRequest, err)
+		}
+		return
+	}
+
+	response.Success(w, http.StatusAccepted, "accepted")
+}
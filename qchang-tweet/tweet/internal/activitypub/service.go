@@ -0,0 +1,229 @@
+package activitypub
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"tweet.io/internal/post"
+	"tweet.io/internal/post/comment"
+	"tweet.io/internal/user"
+)
+
+var (
+	ErrActorNotFound  = errors.New("activitypub: actor not found")
+	ErrNotLocalTarget = errors.New("activitypub: inReplyTo does not target a local post")
+)
+
+type userGetter interface {
+	GetUserByDisplayname(ctx context.Context, displayname string) (*user.User, error)
+}
+
+type postLister interface {
+	GetPostsByUserIDs(ctx context.Context, userIDs []uuid.UUID, viewerID uuid.UUID) ([]*post.Post, error)
+	// GetPost is used by HandleInbox to check an inbound reply's target
+	// against the post's real, current visibility rather than trusting the
+	// activity. It's called with viewerID uuid.Nil, the same as an
+	// anonymous caller would get: public and unlisted posts are visible,
+	// private and followers-only ones are not.
+	GetPost(ctx context.Context, postID, viewerID uuid.UUID) (*post.Post, error)
+}
+
+type commentCreator interface {
+	Create(ctx context.Context, tx pgx.Tx, comment *comment.Comment) error
+}
+
+// followerLister resolves the inbox IRIs of a user's followers so outbound
+// Create activities can be fanned out. It is satisfied by follow.Service.
+type followerLister interface {
+	FollowerInboxIRIs(ctx context.Context, ownerID uuid.UUID) ([]string, error)
+}
+
+// Service implements the federation side of posts and comments: turning
+// local users into actors, listing their outbox, delivering new posts to
+// followers, and accepting inbound replies into the comment tree.
+type Service struct {
+	baseURL  string
+	users    userGetter
+	posts    postLister
+	comments commentCreator
+	follows  followerLister
+	worker   *DeliveryWorker
+}
+
+func NewService(baseURL string, users userGetter, posts postLister, comments commentCreator, follows followerLister, worker *DeliveryWorker) *Service {
+	return &Service{
+		baseURL:  baseURL,
+		users:    users,
+		posts:    posts,
+		comments: comments,
+		follows:  follows,
+		worker:   worker,
+	}
+}
+
+func (s *Service) actorIRI(displayname string) string {
+	return fmt.Sprintf("%s/users/%s", s.baseURL, displayname)
+}
+
+func (s *Service) WebFinger(ctx context.Context, resource string) (*WebFingerResponse, error) {
+	displayname, err := displaynameFromResource(resource)
+	if err != nil {
+		return nil, err
+	}
+
+	u, err := s.users.GetUserByDisplayname(ctx, displayname)
+	if err != nil {
+		return nil, ErrActorNotFound
+	}
+
+	iri := s.actorIRI(u.Displayname)
+
+	return &WebFingerResponse{
+		Subject: resource,
+		Aliases: []string{iri},
+		Links: []WebFingerLink{
+			{Rel: "self", Type: "application/activity+json", Href: iri},
+		},
+	}, nil
+}
+
+func (s *Service) GetActor(ctx context.Context, displayname string) (*Actor, error) {
+	u, err := s.users.GetUserByDisplayname(ctx, displayname)
+	if err != nil {
+		return nil, ErrActorNotFound
+	}
+
+	iri := s.actorIRI(u.Displayname)
+
+	return &Actor{
+		Context:           []string{contextActivityStreams, "https://w3id.org/security/v1"},
+		ID:                iri,
+		Type:              "Person",
+		PreferredUsername: u.Displayname,
+		Name:              u.Firstname + " " + u.Lastname,
+		Inbox:             iri + "/inbox",
+		Outbox:            iri + "/outbox",
+		PublicKey: PublicKey{
+			ID:           iri + "#main-key",
+			Owner:        iri,
+			PublicKeyPem: u.PublicKeyPEM,
+		},
+	}, nil
+}
+
+func (s *Service) GetOutbox(ctx context.Context, displayname string) (*OrderedCollection, error) {
+	u, err := s.users.GetUserByDisplayname(ctx, displayname)
+	if err != nil {
+		return nil, ErrActorNotFound
+	}
+
+	// The outbox is public, federated to anonymous remote servers, so only
+	// public posts are ever listed here.
+	posts, err := s.posts.GetPostsByUserIDs(ctx, []uuid.UUID{u.ID}, uuid.Nil)
+	if err != nil {
+		return nil, err
+	}
+
+	actorIRI := s.actorIRI(u.Displayname)
+	items := make([]Create, 0, len(posts))
+	for _, p := range posts {
+		items = append(items, s.noteCreate(actorIRI, p))
+	}
+
+	return &OrderedCollection{
+		Context:      contextActivityStreams,
+		ID:           actorIRI + "/outbox",
+		Type:         "OrderedCollection",
+		TotalItems:   len(items),
+		OrderedItems: items,
+	}, nil
+}
+
+func (s *Service) noteCreate(actorIRI string, p *post.Post) Create {
+	noteIRI := fmt.Sprintf("%s/posts/%s", s.baseURL, p.ID)
+	published := formatTime(p.CreatedAt)
+
+	return Create{
+		Context:   contextActivityStreams,
+		ID:        noteIRI + "/activity",
+		Type:      "Create",
+		Actor:     actorIRI,
+		Published: published,
+		To:        []string{"https://www.w3.org/ns/activitystreams#Public"},
+		Object: Note{
+			ID:           noteIRI,
+			Type:         "Note",
+			AttributedTo: actorIRI,
+			Content:      p.Content,
+			ContentMap:   map[string]string{p.Language: p.Content},
+			Published:    published,
+			To:           []string{"https://www.w3.org/ns/activitystreams#Public"},
+		},
+	}
+}
+
+// PublishPost signs the post as a Create(Note) activity and enqueues it for
+// delivery to every follower's inbox, so federation never blocks the
+// request that created the post.
+func (s *Service) PublishPost(ctx context.Context, owner *user.User, p *post.Post) error {
+	actorIRI := s.actorIRI(owner.Displayname)
+	create := s.noteCreate(actorIRI, p)
+
+	inboxes, err := s.follows.FollowerInboxIRIs(ctx, owner.ID)
+	if err != nil {
+		return err
+	}
+
+	for _, inbox := range inboxes {
+		s.worker.Enqueue(actorIRI, actorIRI+"#main-key", owner.PrivateKeyPEM, inbox, create)
+	}
+
+	return nil
+}
+
+// HandleInbox verifies and processes an inbound activity. A Create(Note)
+// whose inReplyTo is a local post visible to an anonymous viewer is stored
+// as a comment on that post; a Create(Note) whose inReplyTo is anything
+// else is rejected with ErrNotLocalTarget. Every other activity (including
+// a Create(Note) with no inReplyTo) is accepted and ignored, per the usual
+// ActivityPub posture of not erroring on activity types a server doesn't
+// implement.
+func (s *Service) HandleInbox(ctx context.Context, activity Create, verify func() error) error {
+	if err := verify(); err != nil {
+		return err
+	}
+
+	if activity.Type != "Create" || activity.Object.Type != "Note" || activity.Object.InReplyTo == "" {
+		return nil
+	}
+
+	postID, ok := localPostIDFromIRI(s.baseURL, activity.Object.InReplyTo)
+	if !ok {
+		return ErrNotLocalTarget
+	}
+
+	if _, err := s.posts.GetPost(ctx, postID, uuid.Nil); err != nil {
+		return ErrNotLocalTarget
+	}
+
+	remoteIRI := activity.Object.ID
+	id := uuid.New()
+	c := &comment.Comment{
+		ID: id,
+		// OwnerID is left at its zero value: the comment's author is a
+		// remote actor with no local user record, so there is no owner to
+		// record. RemoteIRI is what marks this comment as federated.
+		OwnerID:   uuid.Nil,
+		PostID:    postID,
+		RemoteIRI: &remoteIRI,
+		Content:   activity.Object.Content,
+		Path:      id.String(),
+		CreatedAt: time.Now(),
+	}
+
+	return s.comments.Create(ctx, nil, c)
+}
@@ -0,0 +1,85 @@
+package activitypub
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func generateTestKeyPair(t *testing.T) (privatePEM, publicPEM string) {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	privateBlock := &pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}
+	publicBytes, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	require.NoError(t, err)
+	publicBlock := &pem.Block{Type: "PUBLIC KEY", Bytes: publicBytes}
+
+	return string(pem.EncodeToMemory(privateBlock)), string(pem.EncodeToMemory(publicBlock))
+}
+
+func newSignedRequest(t *testing.T, privatePEM string, body []byte) *http.Request {
+	t.Helper()
+
+	req := httptest.NewRequest(http.MethodPost, "https://remote.example/inbox", nil)
+	req.Header.Set("Date", "Wed, 29 Jul 2026 00:00:00 GMT")
+
+	require.NoError(t, signRequest(req, "https://local.example/actor#main-key", privatePEM, body))
+
+	return req
+}
+
+func TestSignRequest_VerifyRequest_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	privatePEM, publicPEM := generateTestKeyPair(t)
+	body := []byte(`{"type":"Create"}`)
+
+	req := newSignedRequest(t, privatePEM, body)
+
+	require.NoError(t, verifyRequest(req, body, publicPEM))
+}
+
+func TestVerifyRequest_MissingSignatureHeader(t *testing.T) {
+	t.Parallel()
+
+	_, publicPEM := generateTestKeyPair(t)
+	body := []byte(`{"type":"Create"}`)
+
+	req := httptest.NewRequest(http.MethodPost, "https://remote.example/inbox", nil)
+	req.Header.Set("Date", "Wed, 29 Jul 2026 00:00:00 GMT")
+	req.Header.Set("Digest", digest(body))
+
+	require.ErrorIs(t, verifyRequest(req, body, publicPEM), ErrMissingSignature)
+}
+
+func TestVerifyRequest_TamperedBody(t *testing.T) {
+	t.Parallel()
+
+	privatePEM, publicPEM := generateTestKeyPair(t)
+	body := []byte(`{"type":"Create"}`)
+
+	req := newSignedRequest(t, privatePEM, body)
+
+	require.ErrorIs(t, verifyRequest(req, []byte(`{"type":"Delete"}`), publicPEM), ErrInvalidDigest)
+}
+
+func TestVerifyRequest_WrongKey(t *testing.T) {
+	t.Parallel()
+
+	privatePEM, _ := generateTestKeyPair(t)
+	_, otherPublicPEM := generateTestKeyPair(t)
+	body := []byte(`{"type":"Create"}`)
+
+	req := newSignedRequest(t, privatePEM, body)
+
+	require.ErrorIs(t, verifyRequest(req, body, otherPublicPEM), ErrInvalidSignature)
+}
@@ -0,0 +1,83 @@
+// Package activitypub implements just enough of ActivityPub/ActivityStreams
+// and WebFinger for local users to be followed and replied to by other
+// Fediverse servers: actor discovery, a per-user outbox of Create(Note)
+// activities, and an inbox that accepts inbound Create(Note) replies.
+package activitypub
+
+import "time"
+
+const contextActivityStreams = "https://www.w3.org/ns/activitystreams"
+
+// WebFingerResponse is the JRD returned from /.well-known/webfinger.
+type WebFingerResponse struct {
+	Subject string          `json:"subject"`
+	Links   []WebFingerLink `json:"links"`
+	Aliases []string        `json:"aliases,omitempty"`
+}
+
+type WebFingerLink struct {
+	Rel  string `json:"rel"`
+	Type string `json:"type,omitempty"`
+	Href string `json:"href"`
+}
+
+// PublicKey is embedded in an Actor so other servers can verify HTTP
+// signatures on deliveries made by that actor.
+type PublicKey struct {
+	ID           string `json:"id"`
+	Owner        string `json:"owner"`
+	PublicKeyPem string `json:"publicKeyPem"`
+}
+
+// Actor represents a local user as a federated ActivityPub actor.
+type Actor struct {
+	Context           []string  `json:"@context"`
+	ID                string    `json:"id"`
+	Type              string    `json:"type"`
+	PreferredUsername string    `json:"preferredUsername"`
+	Name              string    `json:"name,omitempty"`
+	Inbox             string    `json:"inbox"`
+	Outbox            string    `json:"outbox"`
+	PublicKey         PublicKey `json:"publicKey"`
+}
+
+// Note is a local post or comment serialized as an ActivityStreams object.
+type Note struct {
+	Context      string `json:"@context,omitempty"`
+	ID           string `json:"id"`
+	Type         string `json:"type"`
+	AttributedTo string `json:"attributedTo"`
+	Content      string `json:"content"`
+	// ContentMap mirrors Content under its BCP-47 language tag, as AS2
+	// readers expect, keyed by the post's declared or detected language.
+	ContentMap map[string]string `json:"contentMap,omitempty"`
+	Published  string            `json:"published"`
+	To         []string          `json:"to,omitempty"`
+	InReplyTo  string            `json:"inReplyTo,omitempty"`
+}
+
+// Create wraps a Note in an ActivityStreams Create activity, which is what
+// actually gets delivered to an inbox or listed in an outbox.
+type Create struct {
+	Context   string   `json:"@context"`
+	ID        string   `json:"id"`
+	Type      string   `json:"type"`
+	Actor     string   `json:"actor"`
+	Published string   `json:"published"`
+	To        []string `json:"to,omitempty"`
+	Object    Note     `json:"object"`
+}
+
+// OrderedCollection is used for the outbox. It carries every item in one
+// collection; it is not split into pages.
+type OrderedCollection struct {
+	Context      string   `json:"@context"`
+	ID           string   `json:"id"`
+	Type         string   `json:"type"`
+	TotalItems   int      `json:"totalItems"`
+	OrderedItems []Create `json:"orderedItems"`
+}
+
+func formatTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
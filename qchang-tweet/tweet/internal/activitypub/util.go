@@ -0,0 +1,42 @@
+package activitypub
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+var ErrInvalidResource = errors.New("activitypub: invalid webfinger resource")
+
+// displaynameFromResource extracts the displayname out of a WebFinger
+// `resource` query parameter of the form "acct:displayname@host".
+func displaynameFromResource(resource string) (string, error) {
+	acct := strings.TrimPrefix(resource, "acct:")
+	if acct == resource {
+		return "", ErrInvalidResource
+	}
+
+	displayname, _, found := strings.Cut(acct, "@")
+	if !found || displayname == "" {
+		return "", ErrInvalidResource
+	}
+
+	return displayname, nil
+}
+
+// localPostIDFromIRI checks whether iri points at a post on this instance
+// and, if so, returns its ID.
+func localPostIDFromIRI(baseURL, iri string) (uuid.UUID, bool) {
+	prefix := baseURL + "/posts/"
+	if !strings.HasPrefix(iri, prefix) {
+		return uuid.Nil, false
+	}
+
+	id, err := uuid.Parse(strings.TrimPrefix(iri, prefix))
+	if err != nil {
+		return uuid.Nil, false
+	}
+
+	return id, true
+}
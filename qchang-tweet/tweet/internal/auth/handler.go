@@ -0,0 +1,201 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"tweet.io/internal/platform/response"
+)
+
+type service interface {
+	Login(ctx context.Context, username, password string) (string, error)
+	AuthCodeURL(sourceID, state string) (string, error)
+	HandleOAuth2Callback(ctx context.Context, sourceID, code string) (string, error)
+	ListSources(ctx context.Context) ([]*SourceRecord, error)
+	CreateSource(ctx context.Context, rec *SourceRecord) error
+	UpdateSource(ctx context.Context, id string, priority int, enabled bool, settings json.RawMessage) error
+	DisableSource(ctx context.Context, id string) error
+}
+
+type handler struct {
+	service service
+}
+
+func NewHandler(service service) *handler {
+	return &handler{service: service}
+}
+
+type LoginRequest struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+}
+
+type LoginResponse struct {
+	Token string `json:"token"`
+}
+
+type OAuth2StartResponse struct {
+	RedirectURL string `json:"redirect_url"`
+}
+
+type OAuth2CallbackRequest struct {
+	Code string `json:"code"`
+}
+
+type SourceResponse struct {
+	ID       string          `json:"id"`
+	Type     string          `json:"type"`
+	Priority int             `json:"priority"`
+	Enabled  bool            `json:"enabled"`
+	Settings json.RawMessage `json:"settings"`
+}
+
+type CreateSourceRequest struct {
+	ID       string          `json:"id"`
+	Type     string          `json:"type"`
+	Priority int             `json:"priority"`
+	Settings json.RawMessage `json:"settings"`
+}
+
+type UpdateSourceRequest struct {
+	Priority int             `json:"priority"`
+	Enabled  bool            `json:"enabled"`
+	Settings json.RawMessage `json:"settings"`
+}
+
+func (h *handler) Login(w http.ResponseWriter, r *http.Request) {
+	var req LoginRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, err)
+		return
+	}
+
+	token, err := h.service.Login(r.Context(), req.Email, req.Password)
+	if err != nil {
+		switch err {
+		case ErrInvalidCredentials:
+			response.Error(w, http.StatusUnauthorized, err)
+		default:
+			response.Error(w, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	response.Success(w, http.StatusOK, LoginResponse{Token: token})
+}
+
+// OAuth2Start returns, as JSON, the URL of {source_id}'s provider that the
+// browser should be sent to in order to begin the authorization-code flow;
+// it does not itself answer with an HTTP redirect.
+func (h *handler) OAuth2Start(w http.ResponseWriter, r *http.Request) {
+	sourceID := mux.Vars(r)["source_id"]
+	state := uuid.New().String()
+
+	redirectURL, err := h.service.AuthCodeURL(sourceID, state)
+	if err != nil {
+		switch err {
+		case ErrSourceNotFound, ErrUnsupportedFlow:
+			response.Error(w, http.StatusBadRequest, err)
+		default:
+			response.Error(w, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	response.Success(w, http.StatusOK, OAuth2StartResponse{RedirectURL: redirectURL})
+}
+
+// OAuth2Callback completes {source_id}'s authorization-code flow and issues
+// a token for the resulting user.
+func (h *handler) OAuth2Callback(w http.ResponseWriter, r *http.Request) {
+	sourceID := mux.Vars(r)["source_id"]
+
+	var req OAuth2CallbackRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, err)
+		return
+	}
+
+	token, err := h.service.HandleOAuth2Callback(r.Context(), sourceID, req.Code)
+	if err != nil {
+		switch err {
+		case ErrSourceNotFound, ErrUnsupportedFlow, ErrInvalidCredentials:
+			response.Error(w, http.StatusBadRequest, err)
+		default:
+			response.Error(w, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	response.Success(w, http.StatusOK, LoginResponse{Token: token})
+}
+
+// ListSources is admin-only: returns every configured external auth source.
+func (h *handler) ListSources(w http.ResponseWriter, r *http.Request) {
+	records, err := h.service.ListSources(r.Context())
+	if err != nil {
+		response.Error(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	resp := make([]SourceResponse, 0, len(records))
+	for _, rec := range records {
+		resp = append(resp, SourceResponse{ID: rec.ID, Type: rec.Type, Priority: rec.Priority, Enabled: rec.Enabled, Settings: rec.Settings})
+	}
+
+	response.Success(w, http.StatusOK, resp)
+}
+
+// CreateSource is admin-only: adds a new external auth source, enabled
+// immediately.
+func (h *handler) CreateSource(w http.ResponseWriter, r *http.Request) {
+	var req CreateSourceRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, err)
+		return
+	}
+
+	rec := &SourceRecord{ID: req.ID, Type: req.Type, Priority: req.Priority, Enabled: true, Settings: req.Settings}
+	if err := h.service.CreateSource(r.Context(), rec); err != nil {
+		response.Error(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	response.Success(w, http.StatusCreated, SourceResponse{ID: rec.ID, Type: rec.Type, Priority: rec.Priority, Enabled: rec.Enabled, Settings: rec.Settings})
+}
+
+// UpdateSource is admin-only: overwrites {source_id}'s priority, enabled
+// state and settings with the request's values. All three are always
+// written, so a field left out of the body is stored as its zero value.
+func (h *handler) UpdateSource(w http.ResponseWriter, r *http.Request) {
+	sourceID := mux.Vars(r)["source_id"]
+
+	var req UpdateSourceRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := h.service.UpdateSource(r.Context(), sourceID, req.Priority, req.Enabled, req.Settings); err != nil {
+		response.Error(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	response.Success(w, http.StatusOK, nil)
+}
+
+// DisableSource is admin-only: disables {source_id} without deleting its
+// configuration.
+func (h *handler) DisableSource(w http.ResponseWriter, r *http.Request) {
+	sourceID := mux.Vars(r)["source_id"]
+
+	if err := h.service.DisableSource(r.Context(), sourceID); err != nil {
+		response.Error(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	response.Success(w, http.StatusOK, nil)
+}
@@ -0,0 +1,44 @@
+package auth
+
+import (
+	"context"
+
+	"tweet.io/internal/user"
+)
+
+// SourceTypeLocal is the built-in source backed by the local users table
+// and a bcrypt password hash. It's always registered, regardless of what
+// the admin endpoints have configured, so login keeps working even before
+// any external source has been added.
+const SourceTypeLocal = "local"
+
+// localUserAuthenticator is satisfied by *user.Service.
+type localUserAuthenticator interface {
+	Authenticate(ctx context.Context, email, password string) (*user.User, error)
+}
+
+type localSource struct {
+	id    string
+	users localUserAuthenticator
+}
+
+func newLocalSource(id string, users localUserAuthenticator) *localSource {
+	return &localSource{id: id, users: users}
+}
+
+func (s *localSource) ID() string { return s.id }
+
+func (s *localSource) Authenticate(ctx context.Context, username, password string) (*ExternalIdentity, error) {
+	u, err := s.users.Authenticate(ctx, username, password)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ExternalIdentity{
+		ExternalID:  u.ID.String(),
+		Email:       u.Email,
+		Firstname:   u.Firstname,
+		Lastname:    u.Lastname,
+		Displayname: u.Displayname,
+	}, nil
+}
@@ -0,0 +1,48 @@
+package auth
+
+import (
+	"net/http"
+	"strings"
+
+	"tweet.io/internal/platform/reqctx"
+)
+
+// AuthMiddleware validates the bearer JWT on every request, rejecting it if
+// missing or invalid, and otherwise attaches the authenticated user's ID and
+// admin status to the request context for downstream handlers.
+func AuthMiddleware(secretKey string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			header := r.Header.Get("Authorization")
+			tokenString := strings.TrimPrefix(header, "Bearer ")
+			if tokenString == "" || tokenString == header {
+				http.Error(w, "missing bearer token", http.StatusUnauthorized)
+				return
+			}
+
+			userID, admin, err := parseToken(secretKey, tokenString)
+			if err != nil {
+				http.Error(w, "invalid token", http.StatusUnauthorized)
+				return
+			}
+
+			ctx := reqctx.WithUserID(r.Context(), userID)
+			ctx = reqctx.WithAdmin(ctx, admin)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// RequireAdmin rejects any request whose token wasn't issued to an admin
+// user. Must run after AuthMiddleware, which is what populates the admin
+// flag this checks.
+func RequireAdmin(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !reqctx.IsAdmin(r.Context()) {
+			http.Error(w, "admin access required", http.StatusForbidden)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
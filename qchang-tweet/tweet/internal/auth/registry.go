@@ -0,0 +1,28 @@
+package auth
+
+// Registry holds the sources Service.Login tries, in the priority order
+// they should be tried in (index 0 first). It's rebuilt from persisted
+// SourceRecords whenever the admin endpoints add, update, or disable a
+// source, so a new LDAP or OAuth2 backend takes effect immediately.
+type Registry struct {
+	sources []Source
+}
+
+func NewRegistry(sources ...Source) *Registry {
+	return &Registry{sources: sources}
+}
+
+// Enabled returns every registered source, in priority order.
+func (r *Registry) Enabled() []Source {
+	return r.sources
+}
+
+func (r *Registry) Get(id string) (Source, bool) {
+	for _, s := range r.sources {
+		if s.ID() == id {
+			return s, true
+		}
+	}
+
+	return nil, false
+}
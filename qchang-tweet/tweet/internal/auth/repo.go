@@ -0,0 +1,121 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var ErrIdentityNotLinked = errors.New("auth: external identity not linked to a user")
+
+// SourceRecord is a configured external auth source as persisted by the
+// admin endpoints: which built-in implementation backs it (Type), whether
+// it's currently usable, and its settings (connection details, client
+// credentials, ...) as a JSON blob whose shape depends on Type.
+type SourceRecord struct {
+	ID        string
+	Type      string
+	Priority  int
+	Enabled   bool
+	Settings  json.RawMessage
+	CreatedAt time.Time
+	UpdatedAt *time.Time
+}
+
+type repo struct {
+	db *pgxpool.Pool
+}
+
+func NewRepository(db *pgxpool.Pool) *repo {
+	return &repo{db: db}
+}
+
+func scanSources(rows pgx.Rows) ([]*SourceRecord, error) {
+	defer rows.Close()
+
+	var records []*SourceRecord
+	for rows.Next() {
+		rec := &SourceRecord{}
+		if err := rows.Scan(&rec.ID, &rec.Type, &rec.Priority, &rec.Enabled, &rec.Settings, &rec.CreatedAt, &rec.UpdatedAt); err != nil {
+			return nil, err
+		}
+
+		records = append(records, rec)
+	}
+
+	return records, rows.Err()
+}
+
+// ListSources returns every configured source, including disabled ones, for
+// the admin listing endpoint.
+func (r *repo) ListSources(ctx context.Context) ([]*SourceRecord, error) {
+	rows, err := r.db.Query(ctx, "SELECT id, type, priority, enabled, settings, created_at, updated_at FROM auth_sources ORDER BY priority ASC")
+	if err != nil {
+		return nil, err
+	}
+
+	return scanSources(rows)
+}
+
+// ListEnabledSources returns enabled sources ordered by priority ascending,
+// the order Service.Login tries them in.
+func (r *repo) ListEnabledSources(ctx context.Context) ([]*SourceRecord, error) {
+	rows, err := r.db.Query(ctx, "SELECT id, type, priority, enabled, settings, created_at, updated_at FROM auth_sources WHERE enabled = true ORDER BY priority ASC")
+	if err != nil {
+		return nil, err
+	}
+
+	return scanSources(rows)
+}
+
+func (r *repo) CreateSource(ctx context.Context, rec *SourceRecord) error {
+	_, err := r.db.Exec(ctx, `
+        INSERT INTO auth_sources (id, type, priority, enabled, settings, created_at)
+        VALUES ($1, $2, $3, $4, $5, now())
+    `, rec.ID, rec.Type, rec.Priority, rec.Enabled, rec.Settings)
+
+	return err
+}
+
+func (r *repo) UpdateSource(ctx context.Context, id string, priority int, enabled bool, settings json.RawMessage) error {
+	_, err := r.db.Exec(ctx, `
+        UPDATE auth_sources SET priority = $1, enabled = $2, settings = $3, updated_at = now() WHERE id = $4
+    `, priority, enabled, settings, id)
+
+	return err
+}
+
+func (r *repo) DisableSource(ctx context.Context, id string) error {
+	_, err := r.db.Exec(ctx, "UPDATE auth_sources SET enabled = false, updated_at = now() WHERE id = $1", id)
+	return err
+}
+
+// LinkExternalIdentity records that sourceID's externalID resolves to
+// userID, upserting so re-provisioning the same identity is idempotent.
+func (r *repo) LinkExternalIdentity(ctx context.Context, userID uuid.UUID, sourceID, externalID string) error {
+	_, err := r.db.Exec(ctx, `
+        INSERT INTO user_auth_sources (user_id, source_id, external_id, created_at)
+        VALUES ($1, $2, $3, now())
+        ON CONFLICT (user_id, source_id) DO UPDATE SET external_id = EXCLUDED.external_id
+    `, userID, sourceID, externalID)
+
+	return err
+}
+
+func (r *repo) FindUserIDByExternalIdentity(ctx context.Context, sourceID, externalID string) (uuid.UUID, error) {
+	var id uuid.UUID
+	err := r.db.QueryRow(ctx, `
+        SELECT user_id FROM user_auth_sources WHERE source_id = $1 AND external_id = $2
+    `, sourceID, externalID).Scan(&id)
+
+	if err == pgx.ErrNoRows {
+		return uuid.Nil, ErrIdentityNotLinked
+	}
+
+	return id, err
+}
@@ -0,0 +1,312 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/google/uuid"
+	"tweet.io/internal/user"
+)
+
+const tokenTTL = time.Hour * 24
+
+type claims struct {
+	jwt.StandardClaims
+	Admin bool `json:"admin"`
+}
+
+func issueToken(secretKey string, userID uuid.UUID, admin bool) (string, error) {
+	now := time.Now()
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims{
+		StandardClaims: jwt.StandardClaims{
+			Subject:   userID.String(),
+			IssuedAt:  now.Unix(),
+			ExpiresAt: now.Add(tokenTTL).Unix(),
+		},
+		Admin: admin,
+	})
+
+	return token.SignedString([]byte(secretKey))
+}
+
+// parseToken validates tokenString's signature and expiry, pinning the
+// signing method to HMAC so a token crafted with alg "none" or a mismatched
+// algorithm is rejected rather than silently accepted.
+func parseToken(secretKey, tokenString string) (uuid.UUID, bool, error) {
+	parsed := &claims{}
+	_, err := jwt.ParseWithClaims(tokenString, parsed, func(t *jwt.Token) (interface{}, error) {
+		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("auth: unexpected signing method %v", t.Header["alg"])
+		}
+
+		return []byte(secretKey), nil
+	})
+	if err != nil {
+		return uuid.Nil, false, err
+	}
+
+	id, err := uuid.Parse(parsed.Subject)
+	if err != nil {
+		return uuid.Nil, false, err
+	}
+
+	return id, parsed.Admin, nil
+}
+
+type userProvisioner interface {
+	Authenticate(ctx context.Context, email, password string) (*user.User, error)
+	ProvisionExternalUser(ctx context.Context, params *user.ProvisionExternalUserParams) (*user.User, error)
+	GetUser(ctx context.Context, id uuid.UUID) (*user.User, error)
+}
+
+type sourceRepository interface {
+	ListSources(ctx context.Context) ([]*SourceRecord, error)
+	ListEnabledSources(ctx context.Context) ([]*SourceRecord, error)
+	CreateSource(ctx context.Context, rec *SourceRecord) error
+	UpdateSource(ctx context.Context, id string, priority int, enabled bool, settings json.RawMessage) error
+	DisableSource(ctx context.Context, id string) error
+	LinkExternalIdentity(ctx context.Context, userID uuid.UUID, sourceID, externalID string) error
+	FindUserIDByExternalIdentity(ctx context.Context, sourceID, externalID string) (uuid.UUID, error)
+}
+
+// Service authenticates against every enabled Source in priority order,
+// issuing a JWT for whichever one first recognizes the credentials, and
+// manages the configured sources behind the admin endpoints. Local is
+// always tried first and can't be disabled; LDAP and OAuth2 sources are
+// loaded from sourceRepository and rebuilt into the in-memory registry
+// every time the admin endpoints change them, so a newly added source takes
+// effect without a restart.
+type Service struct {
+	users       userProvisioner
+	repo        sourceRepository
+	secretKey   string
+	adminEmails map[string]bool
+	ldapDialer  LDAPDialer
+
+	registry *Registry
+}
+
+// NewService builds a Service and loads its initial registry from repo.
+// adminEmails controls which users get the admin claim on login, gating the
+// admin-only source-management endpoints.
+func NewService(ctx context.Context, users userProvisioner, repo sourceRepository, secretKey string, adminEmails []string) (*Service, error) {
+	emails := make(map[string]bool, len(adminEmails))
+	for _, email := range adminEmails {
+		emails[email] = true
+	}
+
+	s := &Service{
+		users:       users,
+		repo:        repo,
+		secretKey:   secretKey,
+		adminEmails: emails,
+	}
+
+	if err := s.reload(ctx); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// SetLDAPDialer wires a real LDAP client into every ldap-type source. Until
+// called, ldap sources report auth failures rather than dialing out.
+func (s *Service) SetLDAPDialer(dialer LDAPDialer) {
+	s.ldapDialer = dialer
+
+	for _, src := range s.registry.Enabled() {
+		if l, ok := src.(*ldapSource); ok {
+			*l = *newLDAPSource(l.id, l.cfg, dialer)
+		}
+	}
+}
+
+// reload rebuilds the in-memory registry from the persisted, enabled
+// sources, always putting the built-in local source first.
+func (s *Service) reload(ctx context.Context) error {
+	records, err := s.repo.ListEnabledSources(ctx)
+	if err != nil {
+		return err
+	}
+
+	sources := make([]Source, 0, len(records)+1)
+	sources = append(sources, newLocalSource(SourceTypeLocal, s.users))
+
+	for _, rec := range records {
+		src, err := s.buildSource(rec)
+		if err != nil {
+			return fmt.Errorf("auth: failed to build source %q: %w", rec.ID, err)
+		}
+
+		sources = append(sources, src)
+	}
+
+	s.registry = NewRegistry(sources...)
+	return nil
+}
+
+func (s *Service) buildSource(rec *SourceRecord) (Source, error) {
+	switch rec.Type {
+	case SourceTypeLDAP:
+		var cfg ldapSourceConfig
+		if err := json.Unmarshal(rec.Settings, &cfg); err != nil {
+			return nil, err
+		}
+
+		return newLDAPSource(rec.ID, cfg, s.ldapDialer), nil
+	case SourceTypeOAuth2:
+		var cfg oauth2SourceConfig
+		if err := json.Unmarshal(rec.Settings, &cfg); err != nil {
+			return nil, err
+		}
+
+		return newOAuth2Source(rec.ID, cfg), nil
+	default:
+		return nil, fmt.Errorf("unknown source type %q", rec.Type)
+	}
+}
+
+// Login tries username/password against every enabled PasswordAuthenticator
+// source in priority order, returning a signed JWT for the first one that
+// recognizes the credentials. A source reporting invalid credentials just
+// moves Login on to the next source rather than failing the whole call.
+func (s *Service) Login(ctx context.Context, username, password string) (string, error) {
+	for _, src := range s.registry.Enabled() {
+		authenticator, ok := src.(PasswordAuthenticator)
+		if !ok {
+			continue
+		}
+
+		identity, err := authenticator.Authenticate(ctx, username, password)
+		switch {
+		case errors.Is(err, ErrInvalidCredentials), errors.Is(err, user.ErrNotFound), errors.Is(err, user.ErrInvalidPassword):
+			continue
+		case err != nil:
+			return "", err
+		}
+
+		return s.finishLogin(ctx, src.ID(), identity)
+	}
+
+	return "", ErrInvalidCredentials
+}
+
+// AuthCodeURL returns the URL to redirect the browser to in order to start
+// sourceID's authorization-code flow.
+func (s *Service) AuthCodeURL(sourceID, state string) (string, error) {
+	src, ok := s.registry.Get(sourceID)
+	if !ok {
+		return "", ErrSourceNotFound
+	}
+
+	code, ok := src.(CodeAuthenticator)
+	if !ok {
+		return "", ErrUnsupportedFlow
+	}
+
+	return code.AuthCodeURL(state), nil
+}
+
+// HandleOAuth2Callback completes sourceID's authorization-code flow for
+// code, returning a signed JWT for the resulting user.
+func (s *Service) HandleOAuth2Callback(ctx context.Context, sourceID, code string) (string, error) {
+	src, ok := s.registry.Get(sourceID)
+	if !ok {
+		return "", ErrSourceNotFound
+	}
+
+	authenticator, ok := src.(CodeAuthenticator)
+	if !ok {
+		return "", ErrUnsupportedFlow
+	}
+
+	identity, err := authenticator.Exchange(ctx, code)
+	if err != nil {
+		return "", err
+	}
+
+	return s.finishLogin(ctx, sourceID, identity)
+}
+
+func (s *Service) finishLogin(ctx context.Context, sourceID string, identity *ExternalIdentity) (string, error) {
+	u, err := s.resolveUser(ctx, sourceID, identity)
+	if err != nil {
+		return "", err
+	}
+
+	return issueToken(s.secretKey, u.ID, s.adminEmails[u.Email])
+}
+
+// resolveUser turns identity, as reported by sourceID, into a local User:
+// the local source's identity already names an existing user by ID, while
+// every external source is matched (or auto-provisioned) by its linked
+// external identity.
+func (s *Service) resolveUser(ctx context.Context, sourceID string, identity *ExternalIdentity) (*user.User, error) {
+	if sourceID == SourceTypeLocal {
+		id, err := uuid.Parse(identity.ExternalID)
+		if err != nil {
+			return nil, err
+		}
+
+		return s.users.GetUser(ctx, id)
+	}
+
+	userID, err := s.repo.FindUserIDByExternalIdentity(ctx, sourceID, identity.ExternalID)
+	if err == nil {
+		return s.users.GetUser(ctx, userID)
+	}
+	if !errors.Is(err, ErrIdentityNotLinked) {
+		return nil, err
+	}
+
+	u, err := s.users.ProvisionExternalUser(ctx, &user.ProvisionExternalUserParams{
+		Email:       identity.Email,
+		Firstname:   identity.Firstname,
+		Lastname:    identity.Lastname,
+		Displayname: identity.Displayname,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.LinkExternalIdentity(ctx, u.ID, sourceID, identity.ExternalID); err != nil {
+		return nil, err
+	}
+
+	return u, nil
+}
+
+// ListSources returns every configured source, including disabled ones.
+func (s *Service) ListSources(ctx context.Context) ([]*SourceRecord, error) {
+	return s.repo.ListSources(ctx)
+}
+
+// CreateSource persists rec and reloads the registry so it takes effect
+// immediately.
+func (s *Service) CreateSource(ctx context.Context, rec *SourceRecord) error {
+	if err := s.repo.CreateSource(ctx, rec); err != nil {
+		return err
+	}
+
+	return s.reload(ctx)
+}
+
+func (s *Service) UpdateSource(ctx context.Context, id string, priority int, enabled bool, settings json.RawMessage) error {
+	if err := s.repo.UpdateSource(ctx, id, priority, enabled, settings); err != nil {
+		return err
+	}
+
+	return s.reload(ctx)
+}
+
+func (s *Service) DisableSource(ctx context.Context, id string) error {
+	if err := s.repo.DisableSource(ctx, id); err != nil {
+		return err
+	}
+
+	return s.reload(ctx)
+}
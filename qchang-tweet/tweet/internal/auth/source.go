@@ -0,0 +1,46 @@
+package auth
+
+import (
+	"context"
+	"errors"
+)
+
+var (
+	ErrInvalidCredentials = errors.New("auth: invalid credentials")
+	ErrUnsupportedFlow    = errors.New("auth: source does not support this authentication flow")
+	ErrSourceNotFound     = errors.New("auth: source not found")
+)
+
+// ExternalIdentity is the profile a Source hands back once it's confirmed
+// who a user is, used to find or provision the matching local User.
+type ExternalIdentity struct {
+	ExternalID  string
+	Email       string
+	Firstname   string
+	Lastname    string
+	Displayname string
+}
+
+// Source is any identity backend authService.Login can try, keyed by the ID
+// it was registered under (the user_auth_sources.source_id column, and the
+// OAuth2 callback path).
+type Source interface {
+	ID() string
+}
+
+// PasswordAuthenticator is a Source that verifies a username/password pair
+// directly. Login tries every enabled source implementing this in priority
+// order until one recognizes the credentials. Satisfied by localSource and
+// ldapSource.
+type PasswordAuthenticator interface {
+	Source
+	Authenticate(ctx context.Context, username, password string) (*ExternalIdentity, error)
+}
+
+// CodeAuthenticator is a Source that authenticates via an authorization-code
+// redirect flow instead of a password. Satisfied by oauth2Source.
+type CodeAuthenticator interface {
+	Source
+	AuthCodeURL(state string) string
+	Exchange(ctx context.Context, code string) (*ExternalIdentity, error)
+}
@@ -0,0 +1,40 @@
+// Package events defines the typed events that flow from the core services
+// through the transactional outbox to the notification service.
+package events
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Type string
+
+const (
+	PostCreated    Type = "post.created"
+	PostUpdated    Type = "post.updated"
+	PostDeleted    Type = "post.deleted"
+	PostLiked      Type = "post.liked"
+	CommentCreated Type = "comment.created"
+	CommentUpdated Type = "comment.updated"
+	CommentDeleted Type = "comment.deleted"
+	FollowCreated  Type = "follow.created"
+)
+
+// Event is the envelope published for every domain write that the
+// notification service may need to deliver to a connected client. OwnerID
+// is the single user who should be notified (e.g. a post's author learning
+// of a new comment on it) — this is a per-owner notification channel, not a
+// timeline/follower fan-out, so e.g. PostCreated only ever reaches the
+// post's own author, never their followers. TargetID is the entity the
+// event is about (e.g. the post or comment ID). Tags is only populated for
+// PostCreated, carrying the hashtags extracted from the post's content so
+// subscribers can filter on them without a round trip to the tag index.
+type Event struct {
+	Type      Type      `json:"type"`
+	OwnerID   uuid.UUID `json:"owner_id"`
+	TargetID  uuid.UUID `json:"target_id"`
+	ActorID   uuid.UUID `json:"actor_id"`
+	Tags      []string  `json:"tags,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
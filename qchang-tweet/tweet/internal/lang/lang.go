@@ -0,0 +1,94 @@
+// Package lang provides a lightweight fallback language detector for posts
+// and comments that don't declare a BCP-47 language tag. It is not meant to
+// rival a real NLP model — it scores the first few hundred characters
+// against a short stopword list per supported language and falls back to
+// DefaultLanguage when nothing matches.
+package lang
+
+import (
+	"strings"
+	"unicode"
+)
+
+// DefaultLanguage is returned when content doesn't match any supported
+// language closely enough to call it, e.g. very short or mixed content.
+const DefaultLanguage = "en"
+
+// Supported lists the BCP-47 tags Detect can return.
+var Supported = []string{"en", "es", "fr", "de", "pt-BR", "ja"}
+
+// sampleLen bounds how much of content is inspected, since stopword
+// frequency is cheap to estimate from a prefix and detection shouldn't pay
+// for scanning an entire long post.
+const sampleLen = 500
+
+// stopwords are a handful of very common, short words per language that
+// rarely double as content words, picked so overlap with the sample is a
+// decent signal without needing a real corpus.
+var stopwords = map[string][]string{
+	"en":    {"the", "and", "is", "are", "was", "were", "this", "that", "with", "for"},
+	"es":    {"el", "la", "los", "las", "y", "es", "para", "con", "pero", "que"},
+	"fr":    {"le", "la", "les", "et", "est", "pour", "avec", "mais", "que", "des"},
+	"de":    {"der", "die", "das", "und", "ist", "für", "mit", "aber", "nicht", "ein"},
+	"pt-BR": {"o", "a", "os", "as", "e", "é", "para", "com", "mas", "que"},
+}
+
+// Detect guesses content's BCP-47 language tag. Japanese is detected by
+// script (hiragana/katakana/kanji), since word-overlap scoring only works
+// for space-separated, Latin-derived languages; everything else is scored
+// by stopword overlap against Supported, defaulting to DefaultLanguage.
+func Detect(content string) string {
+	if len(content) > sampleLen {
+		content = content[:sampleLen]
+	}
+
+	if hasJapaneseScript(content) {
+		return "ja"
+	}
+
+	words := strings.Fields(strings.ToLower(content))
+	if len(words) == 0 {
+		return DefaultLanguage
+	}
+
+	best := DefaultLanguage
+	bestScore := 0
+	for _, code := range Supported {
+		set, ok := stopwords[code]
+		if !ok {
+			continue
+		}
+
+		score := overlap(words, set)
+		if score > bestScore {
+			bestScore = score
+			best = code
+		}
+	}
+
+	return best
+}
+
+func overlap(words, stopwords []string) int {
+	score := 0
+	for _, w := range words {
+		for _, s := range stopwords {
+			if w == s {
+				score++
+				break
+			}
+		}
+	}
+
+	return score
+}
+
+func hasJapaneseScript(content string) bool {
+	for _, r := range content {
+		if unicode.In(r, unicode.Hiragana, unicode.Katakana, unicode.Han) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,165 @@
+package outbox
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/streadway/amqp"
+)
+
+const (
+	exchangeName       = "events"
+	reconnectBaseDelay = time.Second
+	maxReconnectDelay  = time.Second * 30
+)
+
+// AMQPTransport publishes outbox records to a topic exchange with the event
+// type as the routing key. The owner is not part of the key, so a consumer
+// that wants one user's events (as the notification service does) filters
+// on the payload's owner_id itself. It keeps one connection open for
+// the life of the process, transparently redialing if RabbitMQ drops it,
+// and waits for a publisher confirm before reporting a publish as done so
+// the caller only marks an outbox row published once it's actually durable
+// on the broker.
+type AMQPTransport struct {
+	amqpURL string
+
+	mu       sync.Mutex
+	conn     *amqp.Connection
+	ch       *amqp.Channel
+	confirms chan amqp.Confirmation
+	// chClosed fires on a channel-level error, which can happen (e.g. a
+	// protocol exception from a bad publish) while the connection itself
+	// stays open, so ensureConn can't tell a dead channel from a live one
+	// by checking conn.IsClosed() alone.
+	chClosed chan *amqp.Error
+}
+
+func NewAMQPTransport(amqpURL string) (*AMQPTransport, error) {
+	t := &AMQPTransport{amqpURL: amqpURL}
+
+	if err := t.connect(); err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+// connect dials RabbitMQ, opens a confirm-mode channel, and declares the
+// exchange. Callers must hold t.mu.
+func (t *AMQPTransport) connect() error {
+	conn, err := amqp.Dial(t.amqpURL)
+	if err != nil {
+		return fmt.Errorf("outbox: failed to connect to RabbitMQ: %w", err)
+	}
+
+	ch, err := conn.Channel()
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("outbox: failed to open a channel: %w", err)
+	}
+
+	if err := ch.Confirm(false); err != nil {
+		ch.Close()
+		conn.Close()
+		return fmt.Errorf("outbox: failed to put channel into confirm mode: %w", err)
+	}
+
+	if err := ch.ExchangeDeclare(exchangeName, "topic", true, false, false, false, nil); err != nil {
+		ch.Close()
+		conn.Close()
+		return fmt.Errorf("outbox: failed to declare exchange: %w", err)
+	}
+
+	t.conn = conn
+	t.ch = ch
+	t.confirms = ch.NotifyPublish(make(chan amqp.Confirmation, 1))
+	t.chClosed = ch.NotifyClose(make(chan *amqp.Error, 1))
+
+	return nil
+}
+
+// channelClosed reports whether ch's NotifyClose channel has already fired.
+func channelClosed(ch chan *amqp.Error) bool {
+	select {
+	case <-ch:
+		return true
+	default:
+		return false
+	}
+}
+
+// ensureConn returns the current channel, redialing with backoff first if
+// the connection or its channel was dropped since the last publish. Must be
+// called with t.mu held.
+func (t *AMQPTransport) ensureConn(ctx context.Context) (*amqp.Channel, error) {
+	if t.conn != nil && !t.conn.IsClosed() && !channelClosed(t.chClosed) {
+		return t.ch, nil
+	}
+
+	if t.conn != nil {
+		t.ch.Close()
+		t.conn.Close()
+	}
+
+	delay := reconnectBaseDelay
+	for {
+		if err := t.connect(); err == nil {
+			return t.ch, nil
+		} else {
+			log.Printf("outbox: reconnect to RabbitMQ failed, retrying in %s: %v", delay, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(delay):
+		}
+
+		delay *= 2
+		if delay > maxReconnectDelay {
+			delay = maxReconnectDelay
+		}
+	}
+}
+
+func (t *AMQPTransport) Publish(ctx context.Context, routingKey string, payload []byte) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	ch, err := t.ensureConn(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := ch.Publish(exchangeName, routingKey, false, false, amqp.Publishing{
+		ContentType: "application/json",
+		Body:        payload,
+	}); err != nil {
+		return err
+	}
+
+	select {
+	case confirm := <-t.confirms:
+		if !confirm.Ack {
+			return fmt.Errorf("outbox: RabbitMQ nacked publish of %s", routingKey)
+		}
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (t *AMQPTransport) Close() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if err := t.ch.Close(); err != nil {
+		return err
+	}
+
+	return t.conn.Close()
+}
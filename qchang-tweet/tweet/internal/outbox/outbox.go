@@ -0,0 +1,80 @@
+// Package outbox implements the transactional outbox pattern: domain
+// writes enqueue an event row in the same database transaction as the
+// write itself, and a separate Publisher drains the table and ships events
+// to RabbitMQ, so a DB commit and a published event can never diverge.
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"tweet.io/internal/events"
+)
+
+// Record is a single row of the event_outbox table.
+type Record struct {
+	ID          uuid.UUID
+	Type        events.Type
+	Payload     json.RawMessage
+	CreatedAt   time.Time
+	PublishedAt *time.Time
+}
+
+type Repository struct{}
+
+func NewRepository() *Repository {
+	return &Repository{}
+}
+
+// Enqueue writes event into event_outbox using tx, so it is only durably
+// recorded if the rest of tx's writes commit.
+func (r *Repository) Enqueue(ctx context.Context, tx pgx.Tx, event events.Event) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, `
+        INSERT INTO event_outbox (id, type, payload, created_at)
+        VALUES ($1, $2, $3, $4)
+    `, uuid.New(), event.Type, payload, event.CreatedAt)
+
+	return err
+}
+
+// FetchUnpublished locks up to limit unpublished rows with FOR UPDATE SKIP
+// LOCKED so multiple publisher instances can drain the table concurrently
+// without double-sending an event.
+func (r *Repository) FetchUnpublished(ctx context.Context, tx pgx.Tx, limit int) ([]*Record, error) {
+	rows, err := tx.Query(ctx, `
+        SELECT id, type, payload, created_at
+        FROM event_outbox
+        WHERE published_at IS NULL
+        ORDER BY created_at ASC
+        LIMIT $1
+        FOR UPDATE SKIP LOCKED
+    `, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var records []*Record
+	for rows.Next() {
+		rec := &Record{}
+		if err := rows.Scan(&rec.ID, &rec.Type, &rec.Payload, &rec.CreatedAt); err != nil {
+			return nil, err
+		}
+		records = append(records, rec)
+	}
+
+	return records, rows.Err()
+}
+
+func (r *Repository) MarkPublished(ctx context.Context, tx pgx.Tx, id uuid.UUID) error {
+	_, err := tx.Exec(ctx, "UPDATE event_outbox SET published_at = now() WHERE id = $1", id)
+	return err
+}
@@ -0,0 +1,75 @@
+package outbox
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const (
+	pollInterval = time.Second
+	batchSize    = 100
+)
+
+// Transport delivers a single outbox record's payload under routing key.
+// AMQPTransport is the production implementation; tests can supply a fake.
+type Transport interface {
+	Publish(ctx context.Context, routingKey string, payload []byte) error
+}
+
+// Publisher polls event_outbox for unpublished rows and hands them to a
+// Transport, marking each row published only once delivery succeeds.
+type Publisher struct {
+	db        *pgxpool.Pool
+	repo      *Repository
+	transport Transport
+}
+
+func NewPublisher(db *pgxpool.Pool, repo *Repository, transport Transport) *Publisher {
+	return &Publisher{db: db, repo: repo, transport: transport}
+}
+
+// Run polls until ctx is cancelled. Meant to be started in its own
+// goroutine from cmd/main.go.
+func (p *Publisher) Run(ctx context.Context) {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := p.publishBatch(ctx); err != nil {
+				log.Printf("outbox: failed to publish batch: %v", err)
+			}
+		}
+	}
+}
+
+func (p *Publisher) publishBatch(ctx context.Context) error {
+	tx, err := p.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	records, err := p.repo.FetchUnpublished(ctx, tx, batchSize)
+	if err != nil {
+		return err
+	}
+
+	for _, rec := range records {
+		if err := p.transport.Publish(ctx, string(rec.Type), rec.Payload); err != nil {
+			return err
+		}
+
+		if err := p.repo.MarkPublished(ctx, tx, rec.ID); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
@@ -0,0 +1,92 @@
+package comment
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrPostEmpty    = errors.New("post is required")
+	ErrOwnerEmpty   = errors.New("owner is required")
+	ErrEmptyContent = errors.New("content is required")
+)
+
+type Comment struct {
+	ID      uuid.UUID
+	PostID  uuid.UUID
+	OwnerID uuid.UUID
+	Content string
+	// SpoilerText is an optional content warning, round-tripped by the
+	// comment source endpoint the same way as Post.SpoilerText.
+	SpoilerText string
+	Likes       int
+
+	// RemoteIRI is set when the comment originated from a federated
+	// ActivityPub server instead of a local user; nil for local comments.
+	RemoteIRI *string
+	// ParentCommentID points at the local comment this one replies to; nil
+	// for a top level reply to the post.
+	ParentCommentID *uuid.UUID
+	// Path is the materialized ancestry used to order and paginate a
+	// thread: the root comment's ID followed by each descendant's ID, in
+	// reply order, slash-separated (e.g. "root/child/grandchild").
+	Path string
+	// Language is the comment's BCP-47 language tag, either declared by
+	// the author or guessed by lang.Detect.
+	Language string
+	// LanguageDetected is true when Language was guessed rather than
+	// declared.
+	LanguageDetected bool
+
+	CreatedAt time.Time
+	UpdateAt  *time.Time
+}
+
+// NewComment builds a top level reply to a post. Use NewReply to build a
+// reply to another comment.
+func NewComment(postID, ownerID uuid.UUID, content, spoilerText, language string, languageDetected bool) (*Comment, error) {
+	return newComment(postID, ownerID, nil, "", content, spoilerText, language, languageDetected)
+}
+
+// NewReply builds a reply to parentID, whose materialized path is
+// parentPath. The caller is responsible for having already verified that
+// parentID belongs to postID.
+func NewReply(postID, ownerID, parentID uuid.UUID, parentPath, content, spoilerText, language string, languageDetected bool) (*Comment, error) {
+	return newComment(postID, ownerID, &parentID, parentPath, content, spoilerText, language, languageDetected)
+}
+
+func newComment(postID, ownerID uuid.UUID, parentID *uuid.UUID, parentPath, content, spoilerText, language string, languageDetected bool) (*Comment, error) {
+	if postID == uuid.Nil {
+		return nil, ErrPostEmpty
+	}
+
+	if ownerID == uuid.Nil {
+		return nil, ErrOwnerEmpty
+	}
+
+	if content == "" {
+		return nil, ErrEmptyContent
+	}
+
+	id := uuid.New()
+	path := id.String()
+	if parentPath != "" {
+		path = parentPath + "/" + path
+	}
+
+	return &Comment{
+		ID:               id,
+		PostID:           postID,
+		OwnerID:          ownerID,
+		Content:          content,
+		SpoilerText:      spoilerText,
+		Likes:            0,
+		ParentCommentID:  parentID,
+		Path:             path,
+		Language:         language,
+		LanguageDetected: languageDetected,
+		CreatedAt:        time.Now(),
+	}, nil
+}
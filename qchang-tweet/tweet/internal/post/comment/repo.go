@@ -6,37 +6,135 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 var (
 	ErrNotFound = errors.New("follow not found")
 )
 
+// querier is satisfied by both *pgxpool.Pool and pgx.Tx, letting a write
+// method run either against the pool or inside a transaction passed in by
+// the service layer.
+type querier interface {
+	Exec(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row
+}
+
 type repo struct {
-	conn *pgx.Conn
+	db *pgxpool.Pool
+}
+
+func NewRepository(db *pgxpool.Pool) *repo {
+	return &repo{db: db}
 }
 
-func NewRepository(conn *pgx.Conn) *repo {
-	return &repo{conn: conn}
+// WithTx runs fn inside a transaction acquired from the pool, committing on
+// success and rolling back on any error fn returns.
+func (r *repo) WithTx(ctx context.Context, fn func(tx pgx.Tx) error) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// q returns tx if the caller is running inside a transaction, otherwise the
+// repo's pool.
+func (r *repo) q(tx pgx.Tx) querier {
+	if tx != nil {
+		return tx
+	}
+
+	return r.db
 }
 
-func (r *repo) Create(ctx context.Context, comment *Comment) error {
-	_, err := r.conn.Exec(ctx, "INSERT INTO comments (id, post_id, owner_id, content, likes, created_at) VALUES ($1, $2, $3, $4, $5, $6)",
-		comment.ID, comment.PostID, comment.OwnerID, comment.Content, 0, comment.CreatedAt)
+func (r *repo) Create(ctx context.Context, tx pgx.Tx, comment *Comment) error {
+	_, err := r.q(tx).Exec(ctx, `
+        INSERT INTO comments (id, post_id, owner_id, content, spoiler_text, likes, remote_iri, parent_comment_id, path, language, language_detected, created_at)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+    `, comment.ID, comment.PostID, comment.OwnerID, comment.Content, comment.SpoilerText, 0, comment.RemoteIRI, comment.ParentCommentID, comment.Path, comment.Language, comment.LanguageDetected, comment.CreatedAt)
 
 	return err
 }
 
-func (r *repo) Update(ctx context.Context, id, postID, ownerID uuid.UUID, content string) error {
-	_, err := r.conn.Exec(ctx, "UPDATE comments SET content = $1, updated_at = now() WHERE id = $2 AND post_id = $3 AND owner_id = $4", content, id, postID, ownerID)
+func (r *repo) Update(ctx context.Context, tx pgx.Tx, id, postID, ownerID uuid.UUID, content, spoilerText, language string, languageDetected bool) error {
+	_, err := r.q(tx).Exec(ctx, "UPDATE comments SET content = $1, spoiler_text = $2, language = $3, language_detected = $4, updated_at = now() WHERE id = $5 AND post_id = $6 AND owner_id = $7",
+		content, spoilerText, language, languageDetected, id, postID, ownerID)
 
 	return err
 }
 
+// AddRevision snapshots a comment's current content into comment_revisions,
+// called just before Update overwrites it.
+func (r *repo) AddRevision(ctx context.Context, tx pgx.Tx, commentID uuid.UUID, content string) error {
+	_, err := r.q(tx).Exec(ctx, "INSERT INTO comment_revisions (id, parent_id, content, edited_at) VALUES ($1, $2, $3, now())",
+		uuid.New(), commentID, content)
+
+	return err
+}
+
+func (r *repo) GetRevisions(ctx context.Context, commentID uuid.UUID) ([]*Revision, error) {
+	rows, err := r.db.Query(ctx, `
+        SELECT id, parent_id, content, edited_at
+        FROM comment_revisions
+        WHERE parent_id = $1
+        ORDER BY edited_at ASC
+    `, commentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var revisions []*Revision
+	for rows.Next() {
+		rev := &Revision{}
+		if err := rows.Scan(&rev.ID, &rev.ParentID, &rev.Content, &rev.EditedAt); err != nil {
+			return nil, err
+		}
+
+		revisions = append(revisions, rev)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return revisions, nil
+}
+
 func (r *repo) GetComment(ctx context.Context, id, postID, ownerID uuid.UUID) (*Comment, error) {
-	row := r.conn.QueryRow(ctx, "SELECT id, post_id, owner_id, content, created_at, updated_at FROM comments WHERE id = $1 AND post_id = $2 AND owner_id = $3", id, postID, ownerID)
+	row := r.db.QueryRow(ctx, `
+        SELECT id, post_id, owner_id, content, spoiler_text, remote_iri, parent_comment_id, path, language, language_detected, created_at, updated_at
+        FROM comments WHERE id = $1 AND post_id = $2 AND owner_id = $3
+    `, id, postID, ownerID)
 	comment := &Comment{}
-	err := row.Scan(&comment.ID, &comment.PostID, &comment.OwnerID, &comment.Content, &comment.CreatedAt, &comment.UpdateAt)
+	err := row.Scan(&comment.ID, &comment.PostID, &comment.OwnerID, &comment.Content, &comment.SpoilerText, &comment.RemoteIRI, &comment.ParentCommentID, &comment.Path, &comment.Language, &comment.LanguageDetected, &comment.CreatedAt, &comment.UpdateAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return comment, nil
+}
+
+// GetByID looks up a comment by ID alone, without scoping to its owner, so
+// callers can validate that a prospective parent comment exists and belongs
+// to the expected post before a reply is created under it.
+func (r *repo) GetByID(ctx context.Context, id uuid.UUID) (*Comment, error) {
+	row := r.db.QueryRow(ctx, `
+        SELECT id, post_id, owner_id, content, spoiler_text, remote_iri, parent_comment_id, path, language, language_detected, created_at, updated_at
+        FROM comments WHERE id = $1
+    `, id)
+	comment := &Comment{}
+	err := row.Scan(&comment.ID, &comment.PostID, &comment.OwnerID, &comment.Content, &comment.SpoilerText, &comment.RemoteIRI, &comment.ParentCommentID, &comment.Path, &comment.Language, &comment.LanguageDetected, &comment.CreatedAt, &comment.UpdateAt)
 	if err != nil {
 		return nil, err
 	}
@@ -47,13 +145,112 @@ func (r *repo) GetComment(ctx context.Context, id, postID, ownerID uuid.UUID) (*
 func (r *repo) GetComments(ctx context.Context, postID, ownerID uuid.UUID, page, limit int) ([]*Comment, error) {
 	offset := (page - 1) * limit
 	query := `
-		SELECT id, post_id, owner_id, content, created_at, updated_at
+		SELECT id, post_id, owner_id, content, spoiler_text, remote_iri, parent_comment_id, path, language, language_detected, created_at, updated_at
 		FROM comments
 		WHERE post_id = $1 AND owner_id = $2
 		ORDER BY created_at DESC
 		LIMIT $3 OFFSET $4
 	`
-	rows, err := r.conn.Query(ctx, query, postID, ownerID, limit, offset)
+	rows, err := r.db.Query(ctx, query, postID, ownerID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var comments []*Comment
+	for rows.Next() {
+		var comment Comment
+		if err := rows.Scan(&comment.ID, &comment.PostID, &comment.OwnerID, &comment.Content, &comment.SpoilerText, &comment.RemoteIRI, &comment.ParentCommentID, &comment.Path, &comment.Language, &comment.LanguageDetected, &comment.CreatedAt, &comment.UpdateAt); err != nil {
+			return nil, err
+		}
+		comments = append(comments, &comment)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return comments, nil
+}
+
+// GetCommentsThreaded returns a page of root comments (those with no
+// parent) under postID, each immediately followed by its full descendant
+// subtree ordered by path, so replies are interleaved depth-first under
+// their ancestor. Pagination is by root comment, not by row.
+func (r *repo) GetCommentsThreaded(ctx context.Context, postID uuid.UUID, page, limit int) ([]*Comment, error) {
+	offset := (page - 1) * limit
+	rootRows, err := r.db.Query(ctx, `
+        SELECT path FROM comments
+        WHERE post_id = $1 AND parent_comment_id IS NULL
+        ORDER BY created_at DESC
+        LIMIT $2 OFFSET $3
+    `, postID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	var rootPaths []string
+	for rootRows.Next() {
+		var path string
+		if err := rootRows.Scan(&path); err != nil {
+			rootRows.Close()
+			return nil, err
+		}
+		rootPaths = append(rootPaths, path)
+	}
+	rootRows.Close()
+	if err := rootRows.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(rootPaths) == 0 {
+		return nil, nil
+	}
+
+	rows, err := r.db.Query(ctx, `
+        SELECT id, post_id, owner_id, content, spoiler_text, remote_iri, parent_comment_id, path, language, language_detected, created_at, updated_at
+        FROM comments
+        WHERE post_id = $1 AND (path = ANY($2) OR path LIKE ANY(ARRAY(SELECT p || '/%' FROM unnest($2::text[]) AS p)))
+        ORDER BY path ASC
+    `, postID, rootPaths)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var comments []*Comment
+	for rows.Next() {
+		var comment Comment
+		if err := rows.Scan(&comment.ID, &comment.PostID, &comment.OwnerID, &comment.Content, &comment.SpoilerText, &comment.RemoteIRI, &comment.ParentCommentID, &comment.Path, &comment.Language, &comment.LanguageDetected, &comment.CreatedAt, &comment.UpdateAt); err != nil {
+			return nil, err
+		}
+		comments = append(comments, &comment)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return comments, nil
+}
+
+// GetReplies returns a page of parentID's descendants, ordered by path, for
+// lazily loading a deep subtree instead of pulling an entire thread via
+// GetCommentsThreaded.
+func (r *repo) GetReplies(ctx context.Context, postID, parentID uuid.UUID, page, limit int) ([]*Comment, error) {
+	parent, err := r.GetByID(ctx, parentID)
+	if err != nil {
+		return nil, err
+	}
+
+	offset := (page - 1) * limit
+	rows, err := r.db.Query(ctx, `
+        SELECT id, post_id, owner_id, content, spoiler_text, remote_iri, parent_comment_id, path, language, language_detected, created_at, updated_at
+        FROM comments
+        WHERE post_id = $1 AND path LIKE $2
+        ORDER BY path ASC
+        LIMIT $3 OFFSET $4
+    `, postID, parent.Path+"/%", limit, offset)
 	if err != nil {
 		return nil, err
 	}
@@ -62,7 +259,7 @@ func (r *repo) GetComments(ctx context.Context, postID, ownerID uuid.UUID, page,
 	var comments []*Comment
 	for rows.Next() {
 		var comment Comment
-		if err := rows.Scan(&comment.ID, &comment.PostID, &comment.OwnerID, &comment.Content, &comment.CreatedAt, &comment.UpdateAt); err != nil {
+		if err := rows.Scan(&comment.ID, &comment.PostID, &comment.OwnerID, &comment.Content, &comment.SpoilerText, &comment.RemoteIRI, &comment.ParentCommentID, &comment.Path, &comment.Language, &comment.LanguageDetected, &comment.CreatedAt, &comment.UpdateAt); err != nil {
 			return nil, err
 		}
 		comments = append(comments, &comment)
@@ -75,8 +272,8 @@ func (r *repo) GetComments(ctx context.Context, postID, ownerID uuid.UUID, page,
 	return comments, nil
 }
 
-func (r *repo) Delete(ctx context.Context, id, postID, ownerID uuid.UUID) error {
-	_, err := r.conn.Exec(ctx, "DELETE FROM comments WHERE id = $1 AND post_id = $2 AND owner_id = $3", id, postID, ownerID)
+func (r *repo) Delete(ctx context.Context, tx pgx.Tx, id, postID, ownerID uuid.UUID) error {
+	_, err := r.q(tx).Exec(ctx, "DELETE FROM comments WHERE id = $1 AND post_id = $2 AND owner_id = $3", id, postID, ownerID)
 
 	return err
 }
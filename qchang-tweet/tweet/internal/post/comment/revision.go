@@ -0,0 +1,15 @@
+package comment
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Revision is a snapshot of a comment's content immediately before an edit.
+type Revision struct {
+	ID       uuid.UUID
+	ParentID uuid.UUID
+	Content  string
+	EditedAt time.Time
+}
@@ -6,6 +6,7 @@ import (
 	"errors"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -17,37 +18,59 @@ import (
 
 type CreatePostHandlerParams struct {
 	Content string `json:"content"`
+	// SpoilerText is an optional content warning shown in place of
+	// Content until the reader expands it.
+	SpoilerText string `json:"spoiler_text,omitempty"`
+	// Language is an optional author-declared BCP-47 tag; when omitted
+	// the server detects it from Content.
+	Language string `json:"language,omitempty"`
+	// Scope is one of "public", "unlisted", "private", "followers";
+	// omitted defaults to "public".
+	Scope string `json:"scope,omitempty"`
 }
 
 type UpdatePostHandlerParams struct {
-	Content string `json:"content"`
+	Content     string `json:"content"`
+	SpoilerText string `json:"spoiler_text,omitempty"`
+	Language    string `json:"language,omitempty"`
+	// Scope changes the post's visibility; omitted leaves it unchanged.
+	Scope string `json:"scope,omitempty"`
 }
 
 type CreatePostHandlerResponse struct {
-	ID        uuid.UUID  `json:"id"`
-	OwnerID   uuid.UUID  `json:"owner_id"`
-	Content   string     `json:"content"`
-	Likes     int        `json:"likes"`
-	CreatedAt time.Time  `json:"created_at"`
-	UpdatedAt *time.Time `json:"updated_at"`
+	ID               uuid.UUID  `json:"id"`
+	OwnerID          uuid.UUID  `json:"owner_id"`
+	Content          string     `json:"content"`
+	Likes            int        `json:"likes"`
+	Scope            string     `json:"scope"`
+	Language         string     `json:"language"`
+	LanguageDetected bool       `json:"language_detected"`
+	CreatedAt        time.Time  `json:"created_at"`
+	UpdatedAt        *time.Time `json:"updated_at"`
 }
 
 type UpdatePostHandlerResponse struct {
-	ID        uuid.UUID  `json:"id"`
-	OwnerID   uuid.UUID  `json:"owner_id"`
-	Content   string     `json:"content"`
-	Likes     int        `json:"likes"`
-	CreatedAt time.Time  `json:"created_at"`
-	UpdatedAt *time.Time `json:"updated_at"`
+	ID               uuid.UUID  `json:"id"`
+	OwnerID          uuid.UUID  `json:"owner_id"`
+	Content          string     `json:"content"`
+	Likes            int        `json:"likes"`
+	Scope            string     `json:"scope"`
+	Language         string     `json:"language"`
+	LanguageDetected bool       `json:"language_detected"`
+	CreatedAt        time.Time  `json:"created_at"`
+	UpdatedAt        *time.Time `json:"updated_at"`
 }
 
 type GetPostHandlerResponse struct {
-	ID        uuid.UUID  `json:"id"`
-	OwnerID   uuid.UUID  `json:"owner_id"`
-	Content   string     `json:"content"`
-	Likes     int        `json:"likes"`
-	CreatedAt time.Time  `json:"created_at"`
-	UpdatedAt *time.Time `json:"updated_at"`
+	ID               uuid.UUID  `json:"id"`
+	OwnerID          uuid.UUID  `json:"owner_id"`
+	Content          string     `json:"content"`
+	Likes            int        `json:"likes"`
+	Scope            string     `json:"scope"`
+	Language         string     `json:"language"`
+	LanguageDetected bool       `json:"language_detected"`
+	CreatedAt        time.Time  `json:"created_at"`
+	UpdatedAt        *time.Time `json:"updated_at"`
 }
 
 type GetPostsHandlerResponse struct {
@@ -56,58 +79,112 @@ type GetPostsHandlerResponse struct {
 
 type CreateCommentHandlerParams struct {
 	Content string `json:"content"`
+	// SpoilerText is an optional content warning shown in place of
+	// Content until the reader expands it.
+	SpoilerText string `json:"spoiler_text,omitempty"`
+	// ParentID replies to another comment instead of the post directly.
+	ParentID *uuid.UUID `json:"parent_id,omitempty"`
+	Language string     `json:"language,omitempty"`
 }
 
 type UpdateCommentHandlerParams struct {
-	Content string `json:"content"`
+	Content     string `json:"content"`
+	SpoilerText string `json:"spoiler_text,omitempty"`
+	Language    string `json:"language,omitempty"`
 }
 
 type CreateCommentHandlerResponse struct {
-	ID        uuid.UUID  `json:"id"`
-	PostID    uuid.UUID  `json:"post_id"`
-	OwnerID   uuid.UUID  `json:"owner_id"`
-	Content   string     `json:"content"`
-	Likes     int        `json:"likes"`
-	CreatedAt time.Time  `json:"created_at"`
-	UpdatedAt *time.Time `json:"updated_at"`
+	ID               uuid.UUID  `json:"id"`
+	PostID           uuid.UUID  `json:"post_id"`
+	OwnerID          uuid.UUID  `json:"owner_id"`
+	Content          string     `json:"content"`
+	Likes            int        `json:"likes"`
+	ParentID         *uuid.UUID `json:"parent_id,omitempty"`
+	Language         string     `json:"language"`
+	LanguageDetected bool       `json:"language_detected"`
+	CreatedAt        time.Time  `json:"created_at"`
+	UpdatedAt        *time.Time `json:"updated_at"`
 }
 
 type UpdateCommentHandlerResponse struct {
-	ID        uuid.UUID  `json:"id"`
-	PostID    uuid.UUID  `json:"post_id"`
-	OwnerID   uuid.UUID  `json:"owner_id"`
-	Content   string     `json:"content"`
-	Likes     int        `json:"likes"`
-	CreatedAt time.Time  `json:"created_at"`
-	UpdatedAt *time.Time `json:"updated_at"`
+	ID               uuid.UUID  `json:"id"`
+	PostID           uuid.UUID  `json:"post_id"`
+	OwnerID          uuid.UUID  `json:"owner_id"`
+	Content          string     `json:"content"`
+	Likes            int        `json:"likes"`
+	Language         string     `json:"language"`
+	LanguageDetected bool       `json:"language_detected"`
+	CreatedAt        time.Time  `json:"created_at"`
+	UpdatedAt        *time.Time `json:"updated_at"`
 }
 
 type GetCommentHandlerResponse struct {
-	ID        uuid.UUID  `json:"id"`
-	PostID    uuid.UUID  `json:"post_id"`
-	OwnerID   uuid.UUID  `json:"owner_id"`
-	Content   string     `json:"content"`
-	Likes     int        `json:"likes"`
-	CreatedAt time.Time  `json:"created_at"`
-	UpdatedAt *time.Time `json:"updated_at"`
+	ID       uuid.UUID  `json:"id"`
+	PostID   uuid.UUID  `json:"post_id"`
+	OwnerID  uuid.UUID  `json:"owner_id"`
+	Content  string     `json:"content"`
+	Likes    int        `json:"likes"`
+	ParentID *uuid.UUID `json:"parent_id,omitempty"`
+	// Depth is the comment's distance from its thread root, derived from
+	// its materialized path; only meaningful when GetComments was called
+	// with ?thread=true.
+	Depth            int       `json:"depth,omitempty"`
+	Language         string    `json:"language"`
+	LanguageDetected bool      `json:"language_detected"`
+	CreatedAt        time.Time `json:"created_at"`
 }
 
 type GetCommentsHandlerResponse struct {
 	Comments []*GetCommentHandlerResponse `json:"comments"`
 }
 
+type PostRevisionHandlerResponse struct {
+	ID       uuid.UUID `json:"id"`
+	Content  string    `json:"content"`
+	EditedAt time.Time `json:"edited_at"`
+}
+
+type GetPostHistoryHandlerResponse struct {
+	Revisions []*PostRevisionHandlerResponse `json:"revisions"`
+}
+
+type GetPostSourceHandlerResponse struct {
+	Content     string `json:"content"`
+	SpoilerText string `json:"spoiler_text"`
+}
+
+type CommentRevisionHandlerResponse struct {
+	ID       uuid.UUID `json:"id"`
+	Content  string    `json:"content"`
+	EditedAt time.Time `json:"edited_at"`
+}
+
+type GetCommentHistoryHandlerResponse struct {
+	Revisions []*CommentRevisionHandlerResponse `json:"revisions"`
+}
+
+type GetCommentSourceHandlerResponse struct {
+	Content     string `json:"content"`
+	SpoilerText string `json:"spoiler_text"`
+}
+
 type service interface {
 	CreatePost(ctx context.Context, params *CreatePostServiceParams) (*Post, error)
 	UpdatePost(ctx context.Context, postID, ownerID uuid.UUID, params *UpdatePostServiceParams) (*Post, error)
 	GetPost(ctx context.Context, postID, ownerID uuid.UUID) (*Post, error)
-	GetPosts(ctx context.Context, ownerID uuid.UUID, page int, limit int) ([]*Post, error)
+	GetPosts(ctx context.Context, ownerID uuid.UUID, language string, page int, limit int) ([]*Post, error)
 	DeletePost(ctx context.Context, postID, ownerID uuid.UUID) error
 	AddLike(ctx context.Context, postID, ownerID uuid.UUID) error
 	CreateComment(ctx context.Context, params *CreateCommentServiceParams) (*comment.Comment, error)
 	UpdateComment(ctx context.Context, params *UpdateCommentServiceParams) (*comment.Comment, error)
 	GetComment(ctx context.Context, id uuid.UUID, params *GetCommentServiceParams) (*comment.Comment, error)
 	GetComments(ctx context.Context, page, limit int, params *GetCommentsServiceParams) ([]*comment.Comment, error)
+	GetReplies(ctx context.Context, parentID uuid.UUID, page, limit int, params *GetRepliesServiceParams) ([]*comment.Comment, error)
 	DeleteComment(ctx context.Context, commentID, postID, ownerID uuid.UUID) error
+	GetPostHistory(ctx context.Context, postID, ownerID uuid.UUID) ([]*PostRevision, error)
+	GetPostSource(ctx context.Context, postID, ownerID uuid.UUID) (*Post, error)
+	GetCommentHistory(ctx context.Context, id uuid.UUID, params *GetCommentServiceParams) ([]*comment.Revision, error)
+	GetCommentSource(ctx context.Context, id uuid.UUID, params *GetCommentServiceParams) (*comment.Comment, error)
 }
 
 type handler struct {
@@ -132,21 +209,32 @@ func (h *handler) CreatePost(w http.ResponseWriter, r *http.Request) {
 	}
 
 	post, err := h.service.CreatePost(r.Context(), &CreatePostServiceParams{
-		OwnerID: userID,
-		Content: params.Content,
+		OwnerID:     userID,
+		Content:     params.Content,
+		SpoilerText: params.SpoilerText,
+		Language:    params.Language,
+		Scope:       params.Scope,
 	})
 	if err != nil {
-		response.Error(w, http.StatusInternalServerError, err)
+		switch err {
+		case ErrInvalidScope:
+			response.Error(w, http.StatusBadRequest, err)
+		default:
+			response.Error(w, http.StatusInternalServerError, err)
+		}
 		return
 	}
 
 	resp := CreatePostHandlerResponse{
-		ID:        post.ID,
-		OwnerID:   post.OwnerID,
-		Content:   post.Content,
-		Likes:     post.Likes,
-		CreatedAt: post.CreatedAt,
-		UpdatedAt: post.UpdatedAt,
+		ID:               post.ID,
+		OwnerID:          post.OwnerID,
+		Content:          post.Content,
+		Likes:            post.Likes,
+		Scope:            post.Scope,
+		Language:         post.Language,
+		LanguageDetected: post.LanguageDetected,
+		CreatedAt:        post.CreatedAt,
+		UpdatedAt:        post.UpdatedAt,
 	}
 
 	response.Success(w, http.StatusOK, resp)
@@ -172,12 +260,17 @@ func (h *handler) UpdatePost(w http.ResponseWriter, r *http.Request) {
 	}
 
 	post, err := h.service.UpdatePost(r.Context(), postID, userID, &UpdatePostServiceParams{
-		Content: params.Content,
+		Content:     params.Content,
+		SpoilerText: params.SpoilerText,
+		Language:    params.Language,
+		Scope:       params.Scope,
 	})
 	if err != nil {
 		switch err {
 		case ErrPostNotFound:
 			response.Error(w, http.StatusNotFound, err)
+		case ErrInvalidScope:
+			response.Error(w, http.StatusBadRequest, err)
 		default:
 			response.Error(w, http.StatusInternalServerError, err)
 		}
@@ -185,12 +278,15 @@ func (h *handler) UpdatePost(w http.ResponseWriter, r *http.Request) {
 	}
 
 	resp := UpdatePostHandlerResponse{
-		ID:        post.ID,
-		OwnerID:   post.OwnerID,
-		Content:   post.Content,
-		Likes:     post.Likes,
-		CreatedAt: post.CreatedAt,
-		UpdatedAt: post.UpdatedAt,
+		ID:               post.ID,
+		OwnerID:          post.OwnerID,
+		Content:          post.Content,
+		Likes:            post.Likes,
+		Scope:            post.Scope,
+		Language:         post.Language,
+		LanguageDetected: post.LanguageDetected,
+		CreatedAt:        post.CreatedAt,
+		UpdatedAt:        post.UpdatedAt,
 	}
 
 	response.Success(w, http.StatusOK, resp)
@@ -221,12 +317,15 @@ func (h *handler) GetPost(w http.ResponseWriter, r *http.Request) {
 	}
 
 	resp := UpdatePostHandlerResponse{
-		ID:        post.ID,
-		OwnerID:   post.OwnerID,
-		Content:   post.Content,
-		Likes:     post.Likes,
-		CreatedAt: post.CreatedAt,
-		UpdatedAt: post.UpdatedAt,
+		ID:               post.ID,
+		OwnerID:          post.OwnerID,
+		Content:          post.Content,
+		Likes:            post.Likes,
+		Scope:            post.Scope,
+		Language:         post.Language,
+		LanguageDetected: post.LanguageDetected,
+		CreatedAt:        post.CreatedAt,
+		UpdatedAt:        post.UpdatedAt,
 	}
 
 	response.Success(w, http.StatusOK, resp)
@@ -258,7 +357,9 @@ func (h *handler) GetPosts(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	posts, err := h.service.GetPosts(r.Context(), userID, page, limit)
+	language := r.URL.Query().Get("language")
+
+	posts, err := h.service.GetPosts(r.Context(), userID, language, page, limit)
 	if err != nil {
 		response.Error(w, http.StatusInternalServerError, err)
 		return
@@ -267,12 +368,15 @@ func (h *handler) GetPosts(w http.ResponseWriter, r *http.Request) {
 	resp := &GetPostsHandlerResponse{}
 	for _, p := range posts {
 		resp.Posts = append(resp.Posts, &GetPostHandlerResponse{
-			ID:        p.ID,
-			OwnerID:   p.OwnerID,
-			Content:   p.Content,
-			Likes:     p.Likes,
-			CreatedAt: p.CreatedAt,
-			UpdatedAt: p.UpdatedAt,
+			ID:               p.ID,
+			OwnerID:          p.OwnerID,
+			Content:          p.Content,
+			Likes:            p.Likes,
+			Scope:            p.Scope,
+			Language:         p.Language,
+			LanguageDetected: p.LanguageDetected,
+			CreatedAt:        p.CreatedAt,
+			UpdatedAt:        p.UpdatedAt,
 		})
 	}
 
@@ -355,14 +459,19 @@ func (h *handler) CreateComment(w http.ResponseWriter, r *http.Request) {
 	}
 
 	newComment, err := h.service.CreateComment(r.Context(), &CreateCommentServiceParams{
-		PostID:  postID,
-		OwnerID: userID,
-		Content: params.Content,
+		PostID:      postID,
+		OwnerID:     userID,
+		Content:     params.Content,
+		SpoilerText: params.SpoilerText,
+		ParentID:    params.ParentID,
+		Language:    params.Language,
 	})
 	if err != nil {
 		switch err {
 		case comment.ErrPostEmpty, comment.ErrEmptyContent, comment.ErrOwnerEmpty:
 			response.Error(w, http.StatusBadRequest, err)
+		case ErrParentNotFound, ErrParentPostMismatch:
+			response.Error(w, http.StatusBadRequest, err)
 		default:
 			response.Error(w, http.StatusInternalServerError, err)
 		}
@@ -371,12 +480,15 @@ func (h *handler) CreateComment(w http.ResponseWriter, r *http.Request) {
 	}
 
 	resp := &CreateCommentHandlerResponse{
-		ID:        newComment.ID,
-		PostID:    newComment.PostID,
-		OwnerID:   newComment.OwnerID,
-		Content:   newComment.Content,
-		Likes:     newComment.Likes,
-		CreatedAt: newComment.CreatedAt,
+		ID:               newComment.ID,
+		PostID:           newComment.PostID,
+		OwnerID:          newComment.OwnerID,
+		Content:          newComment.Content,
+		Likes:            newComment.Likes,
+		ParentID:         newComment.ParentCommentID,
+		Language:         newComment.Language,
+		LanguageDetected: newComment.LanguageDetected,
+		CreatedAt:        newComment.CreatedAt,
 	}
 
 	response.Success(w, http.StatusOK, resp)
@@ -408,10 +520,12 @@ func (h *handler) UpdateComment(w http.ResponseWriter, r *http.Request) {
 	}
 
 	comment, err := h.service.UpdateComment(r.Context(), &UpdateCommentServiceParams{
-		ID:      commentID,
-		PostID:  postID,
-		OwnerID: userID,
-		Content: params.Content,
+		ID:          commentID,
+		PostID:      postID,
+		OwnerID:     userID,
+		Content:     params.Content,
+		SpoilerText: params.SpoilerText,
+		Language:    params.Language,
 	})
 
 	if err != nil {
@@ -425,12 +539,14 @@ func (h *handler) UpdateComment(w http.ResponseWriter, r *http.Request) {
 	}
 
 	resp := &UpdateCommentHandlerResponse{
-		ID:        comment.ID,
-		PostID:    comment.PostID,
-		OwnerID:   comment.OwnerID,
-		Content:   comment.Content,
-		Likes:     comment.Likes,
-		CreatedAt: comment.CreatedAt,
+		ID:               comment.ID,
+		PostID:           comment.PostID,
+		OwnerID:          comment.OwnerID,
+		Content:          comment.Content,
+		Likes:            comment.Likes,
+		Language:         comment.Language,
+		LanguageDetected: comment.LanguageDetected,
+		CreatedAt:        comment.CreatedAt,
 	}
 
 	response.Success(w, http.StatusOK, resp)
@@ -498,12 +614,16 @@ func (h *handler) GetComment(w http.ResponseWriter, r *http.Request) {
 	}
 
 	resp := &GetCommentHandlerResponse{
-		ID:        comment.ID,
-		PostID:    comment.PostID,
-		OwnerID:   comment.OwnerID,
-		Content:   comment.Content,
-		Likes:     comment.Likes,
-		CreatedAt: comment.CreatedAt,
+		ID:               comment.ID,
+		PostID:           comment.PostID,
+		OwnerID:          comment.OwnerID,
+		Content:          comment.Content,
+		Likes:            comment.Likes,
+		ParentID:         comment.ParentCommentID,
+		Depth:            commentDepth(comment.Path),
+		Language:         comment.Language,
+		LanguageDetected: comment.LanguageDetected,
+		CreatedAt:        comment.CreatedAt,
 	}
 
 	response.Success(w, http.StatusOK, resp)
@@ -540,9 +660,12 @@ func (h *handler) GetComments(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	thread := r.URL.Query().Get("thread") == "true"
+
 	comments, err := h.service.GetComments(r.Context(), page, limit, &GetCommentsServiceParams{
 		PostID:  postID,
 		OwnerID: userID,
+		Thread:  thread,
 	})
 	if err != nil {
 		response.Error(w, http.StatusInternalServerError, err)
@@ -552,14 +675,234 @@ func (h *handler) GetComments(w http.ResponseWriter, r *http.Request) {
 	resp := &GetCommentsHandlerResponse{}
 	for _, p := range comments {
 		resp.Comments = append(resp.Comments, &GetCommentHandlerResponse{
-			ID:        p.ID,
-			PostID:    p.PostID,
-			OwnerID:   p.OwnerID,
-			Content:   p.Content,
-			Likes:     p.Likes,
-			CreatedAt: p.CreatedAt,
+			ID:               p.ID,
+			PostID:           p.PostID,
+			OwnerID:          p.OwnerID,
+			Content:          p.Content,
+			Likes:            p.Likes,
+			ParentID:         p.ParentCommentID,
+			Depth:            commentDepth(p.Path),
+			Language:         p.Language,
+			LanguageDetected: p.LanguageDetected,
+			CreatedAt:        p.CreatedAt,
+		})
+	}
+
+	response.Success(w, http.StatusOK, resp)
+}
+
+// GetReplies returns a page of {comment_id}'s descendants, for lazily
+// loading a deep subtree instead of pulling the whole thread via
+// ?thread=true.
+func (h *handler) GetReplies(w http.ResponseWriter, r *http.Request) {
+	postID, err := uuid.Parse(mux.Vars(r)["post_id"])
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, err)
+		return
+	}
+
+	commentID, err := uuid.Parse(mux.Vars(r)["comment_id"])
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, err)
+		return
+	}
+
+	userID, ok := reqctx.UserIDFromContext(r.Context())
+	if !ok {
+		response.Error(w, http.StatusInternalServerError, errors.New("user not found"))
+		return
+	}
+
+	page := 1
+	if p, err := strconv.Atoi(r.URL.Query().Get("page")); err == nil && p > 0 {
+		page = p
+	}
+
+	limit := 10
+	if l, err := strconv.Atoi(r.URL.Query().Get("limit")); err == nil && l > 0 {
+		limit = l
+	}
+
+	replies, err := h.service.GetReplies(r.Context(), commentID, page, limit, &GetRepliesServiceParams{
+		PostID:  postID,
+		OwnerID: userID,
+	})
+	if err != nil {
+		response.Error(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	resp := &GetCommentsHandlerResponse{}
+	for _, p := range replies {
+		resp.Comments = append(resp.Comments, &GetCommentHandlerResponse{
+			ID:               p.ID,
+			PostID:           p.PostID,
+			OwnerID:          p.OwnerID,
+			Content:          p.Content,
+			Likes:            p.Likes,
+			ParentID:         p.ParentCommentID,
+			Depth:            commentDepth(p.Path),
+			Language:         p.Language,
+			LanguageDetected: p.LanguageDetected,
+			CreatedAt:        p.CreatedAt,
 		})
 	}
 
 	response.Success(w, http.StatusOK, resp)
 }
+
+// commentDepth derives a comment's distance from its thread root by
+// counting the separators in its materialized path.
+func commentDepth(path string) int {
+	return strings.Count(path, "/")
+}
+
+func (h *handler) GetPostHistory(w http.ResponseWriter, r *http.Request) {
+	postID, err := uuid.Parse(mux.Vars(r)["post_id"])
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, err)
+		return
+	}
+
+	userID, ok := reqctx.UserIDFromContext(r.Context())
+	if !ok {
+		response.Error(w, http.StatusInternalServerError, errors.New("user not found"))
+		return
+	}
+
+	revisions, err := h.service.GetPostHistory(r.Context(), postID, userID)
+	if err != nil {
+		switch err {
+		case ErrPostNotFound:
+			response.Error(w, http.StatusNotFound, err)
+		default:
+			response.Error(w, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	resp := &GetPostHistoryHandlerResponse{}
+	for _, rev := range revisions {
+		resp.Revisions = append(resp.Revisions, &PostRevisionHandlerResponse{
+			ID:       rev.ID,
+			Content:  rev.Content,
+			EditedAt: rev.EditedAt,
+		})
+	}
+
+	response.Success(w, http.StatusOK, resp)
+}
+
+func (h *handler) GetPostSource(w http.ResponseWriter, r *http.Request) {
+	postID, err := uuid.Parse(mux.Vars(r)["post_id"])
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, err)
+		return
+	}
+
+	userID, ok := reqctx.UserIDFromContext(r.Context())
+	if !ok {
+		response.Error(w, http.StatusInternalServerError, errors.New("user not found"))
+		return
+	}
+
+	post, err := h.service.GetPostSource(r.Context(), postID, userID)
+	if err != nil {
+		switch err {
+		case ErrPostNotFound:
+			response.Error(w, http.StatusNotFound, err)
+		default:
+			response.Error(w, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	response.Success(w, http.StatusOK, GetPostSourceHandlerResponse{
+		Content:     post.Content,
+		SpoilerText: post.SpoilerText,
+	})
+}
+
+func (h *handler) GetCommentHistory(w http.ResponseWriter, r *http.Request) {
+	postID, err := uuid.Parse(mux.Vars(r)["post_id"])
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, err)
+		return
+	}
+
+	commentID, err := uuid.Parse(mux.Vars(r)["comment_id"])
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, err)
+		return
+	}
+
+	userID, ok := reqctx.UserIDFromContext(r.Context())
+	if !ok {
+		response.Error(w, http.StatusInternalServerError, errors.New("user not found"))
+		return
+	}
+
+	revisions, err := h.service.GetCommentHistory(r.Context(), commentID, &GetCommentServiceParams{
+		PostID:  postID,
+		OwnerID: userID,
+	})
+	if err != nil {
+		switch err {
+		case ErrPostNotFound:
+			response.Error(w, http.StatusNotFound, err)
+		default:
+			response.Error(w, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	resp := &GetCommentHistoryHandlerResponse{}
+	for _, rev := range revisions {
+		resp.Revisions = append(resp.Revisions, &CommentRevisionHandlerResponse{
+			ID:       rev.ID,
+			Content:  rev.Content,
+			EditedAt: rev.EditedAt,
+		})
+	}
+
+	response.Success(w, http.StatusOK, resp)
+}
+
+func (h *handler) GetCommentSource(w http.ResponseWriter, r *http.Request) {
+	postID, err := uuid.Parse(mux.Vars(r)["post_id"])
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, err)
+		return
+	}
+
+	commentID, err := uuid.Parse(mux.Vars(r)["comment_id"])
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, err)
+		return
+	}
+
+	userID, ok := reqctx.UserIDFromContext(r.Context())
+	if !ok {
+		response.Error(w, http.StatusInternalServerError, errors.New("user not found"))
+		return
+	}
+
+	comment, err := h.service.GetCommentSource(r.Context(), commentID, &GetCommentServiceParams{
+		PostID:  postID,
+		OwnerID: userID,
+	})
+	if err != nil {
+		switch err {
+		case ErrPostNotFound:
+			response.Error(w, http.StatusNotFound, err)
+		default:
+			response.Error(w, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	response.Success(w, http.StatusOK, GetCommentSourceHandlerResponse{
+		Content:     comment.Content,
+		SpoilerText: comment.SpoilerText,
+	})
+}
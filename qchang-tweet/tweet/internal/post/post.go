@@ -0,0 +1,83 @@
+package post
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrOwnerEmpty   = errors.New("owner is required")
+	ErrEmptyContent = errors.New("content is required")
+	ErrInvalidScope = errors.New("invalid scope")
+)
+
+// Scope values control who may read a post.
+const (
+	ScopePublic    = "public"
+	ScopeUnlisted  = "unlisted"
+	ScopePrivate   = "private"
+	ScopeFollowers = "followers"
+)
+
+var validScopes = map[string]bool{
+	ScopePublic:    true,
+	ScopeUnlisted:  true,
+	ScopePrivate:   true,
+	ScopeFollowers: true,
+}
+
+type Post struct {
+	ID      uuid.UUID
+	OwnerID uuid.UUID
+	Content string
+	// SpoilerText is an optional content warning shown in place of Content
+	// until the reader expands it; round-tripped by the source endpoint so
+	// editors can re-submit a post unchanged.
+	SpoilerText string
+	Likes       int
+	// Scope controls who may read the post: public (default), unlisted
+	// (readable by ID but hidden from listings/feeds), private (owner
+	// only), or followers (owner's followers only).
+	Scope string
+	// Language is the post's BCP-47 language tag (e.g. "en", "ja",
+	// "pt-BR"), either declared by the author or guessed by lang.Detect.
+	Language string
+	// LanguageDetected is true when Language was guessed rather than
+	// declared, so clients can tell user-declared from inferred.
+	LanguageDetected bool
+	CreatedAt        time.Time
+	UpdatedAt        *time.Time
+}
+
+// NewPost builds a post, defaulting scope to public when the caller
+// doesn't declare one.
+func NewPost(ownerID uuid.UUID, content, spoilerText, language, scope string, languageDetected bool) (*Post, error) {
+	if ownerID == uuid.Nil {
+		return nil, ErrOwnerEmpty
+	}
+
+	if content == "" {
+		return nil, ErrEmptyContent
+	}
+
+	if scope == "" {
+		scope = ScopePublic
+	} else if !validScopes[scope] {
+		return nil, ErrInvalidScope
+	}
+
+	return &Post{
+		ID:               uuid.New(),
+		OwnerID:          ownerID,
+		Content:          content,
+		SpoilerText:      spoilerText,
+		Likes:            0,
+		Scope:            scope,
+		Language:         language,
+		LanguageDetected: languageDetected,
+		CreatedAt:        time.Now(),
+		UpdatedAt:        nil,
+	}, nil
+}
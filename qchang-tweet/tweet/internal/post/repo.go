@@ -5,33 +5,130 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
 )
 
+// querier is satisfied by both *pgxpool.Pool and pgx.Tx, letting a write
+// method run either against the pool or inside a transaction passed in by
+// the service layer.
+type querier interface {
+	Exec(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row
+}
+
 type repo struct {
-	conn *pgx.Conn
+	db *pgxpool.Pool
+}
+
+func NewRepository(db *pgxpool.Pool) *repo {
+	return &repo{db: db}
+}
+
+// Begin acquires a connection from the pool and starts a transaction on it
+// so the service layer can write to several repos and enqueue an outbox
+// event atomically.
+func (r *repo) Begin(ctx context.Context) (pgx.Tx, error) {
+	return r.db.Begin(ctx)
+}
+
+// WithTx runs fn inside a transaction acquired from the pool, committing on
+// success and rolling back on any error fn returns.
+func (r *repo) WithTx(ctx context.Context, fn func(tx pgx.Tx) error) error {
+	tx, err := r.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
 }
 
-func NewRepository(conn *pgx.Conn) *repo {
-	return &repo{conn: conn}
+// q returns tx if the caller is running inside a transaction, otherwise the
+// repo's pool.
+func (r *repo) q(tx pgx.Tx) querier {
+	if tx != nil {
+		return tx
+	}
+
+	return r.db
 }
 
-func (r *repo) CreatePost(ctx context.Context, post *Post) error {
-	_, err := r.conn.Exec(ctx, "INSERT INTO posts (id, owner_id, content, likes, created_at) VALUES ($1, $2, $3, $4, $5)",
-		post.ID, post.OwnerID, post.Content, post.Likes, post.CreatedAt)
+func (r *repo) CreatePost(ctx context.Context, tx pgx.Tx, post *Post) error {
+	_, err := r.q(tx).Exec(ctx, "INSERT INTO posts (id, owner_id, content, spoiler_text, likes, scope, language, language_detected, created_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)",
+		post.ID, post.OwnerID, post.Content, post.SpoilerText, post.Likes, post.Scope, post.Language, post.LanguageDetected, post.CreatedAt)
 
 	return err
 }
 
-func (r *repo) UpdatePost(ctx context.Context, postID, userID uuid.UUID, content string) error {
-	_, err := r.conn.Exec(ctx, "UPDATE posts SET content = $1, updated_at = now() WHERE id = $2 AND owner_id = $3", content, postID, userID)
+func (r *repo) UpdatePost(ctx context.Context, tx pgx.Tx, postID, userID uuid.UUID, content, spoilerText, scope, language string, languageDetected bool) error {
+	_, err := r.q(tx).Exec(ctx, "UPDATE posts SET content = $1, spoiler_text = $2, scope = $3, language = $4, language_detected = $5, updated_at = now() WHERE id = $6 AND owner_id = $7",
+		content, spoilerText, scope, language, languageDetected, postID, userID)
 
 	return err
 }
 
+// AddRevision snapshots a post's current content into post_revisions,
+// called just before UpdatePost overwrites it.
+func (r *repo) AddRevision(ctx context.Context, tx pgx.Tx, postID uuid.UUID, content string) error {
+	_, err := r.q(tx).Exec(ctx, "INSERT INTO post_revisions (id, parent_id, content, edited_at) VALUES ($1, $2, $3, now())",
+		uuid.New(), postID, content)
+
+	return err
+}
+
+func (r *repo) GetRevisions(ctx context.Context, postID uuid.UUID) ([]*PostRevision, error) {
+	rows, err := r.db.Query(ctx, `
+        SELECT id, parent_id, content, edited_at
+        FROM post_revisions
+        WHERE parent_id = $1
+        ORDER BY edited_at ASC
+    `, postID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var revisions []*PostRevision
+	for rows.Next() {
+		rev := &PostRevision{}
+		if err := rows.Scan(&rev.ID, &rev.PostID, &rev.Content, &rev.EditedAt); err != nil {
+			return nil, err
+		}
+
+		revisions = append(revisions, rev)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return revisions, nil
+}
+
 func (r *repo) GetPost(ctx context.Context, postID, userID uuid.UUID) (*Post, error) {
-	row := r.conn.QueryRow(ctx, "SELECT id, owner_id, content, likes, created_at, updated_at FROM posts WHERE id = $1 AND owner_id = $2", postID, userID)
+	row := r.db.QueryRow(ctx, "SELECT id, owner_id, content, spoiler_text, likes, scope, language, language_detected, created_at, updated_at FROM posts WHERE id = $1 AND owner_id = $2", postID, userID)
+	post := &Post{}
+	err := row.Scan(&post.ID, &post.OwnerID, &post.Content, &post.SpoilerText, &post.Likes, &post.Scope, &post.Language, &post.LanguageDetected, &post.CreatedAt, &post.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return post, nil
+}
+
+// GetByID looks up a post by ID alone, without scoping to its owner, so
+// callers can enforce visibility scopes against viewers other than the
+// post's author.
+func (r *repo) GetByID(ctx context.Context, id uuid.UUID) (*Post, error) {
+	row := r.db.QueryRow(ctx, "SELECT id, owner_id, content, spoiler_text, likes, scope, language, language_detected, created_at, updated_at FROM posts WHERE id = $1", id)
 	post := &Post{}
-	err := row.Scan(&post.ID, &post.OwnerID, &post.Content, &post.Likes, &post.CreatedAt, &post.UpdatedAt)
+	err := row.Scan(&post.ID, &post.OwnerID, &post.Content, &post.SpoilerText, &post.Likes, &post.Scope, &post.Language, &post.LanguageDetected, &post.CreatedAt, &post.UpdatedAt)
 	if err != nil {
 		return nil, err
 	}
@@ -39,15 +136,17 @@ func (r *repo) GetPost(ctx context.Context, postID, userID uuid.UUID) (*Post, er
 	return post, nil
 }
 
-func (r *repo) GetPosts(ctx context.Context, userID uuid.UUID, page int, limit int) ([]*Post, error) {
+// GetPosts returns a page of userID's posts, most recent first, optionally
+// restricted to language (empty matches any language).
+func (r *repo) GetPosts(ctx context.Context, userID uuid.UUID, language string, page int, limit int) ([]*Post, error) {
 	offset := (page - 1) * limit
-	rows, err := r.conn.Query(ctx, `
-        SELECT id, owner_id, content, likes, created_at, updated_at
+	rows, err := r.db.Query(ctx, `
+        SELECT id, owner_id, content, spoiler_text, likes, scope, language, language_detected, created_at, updated_at
         FROM posts
-        WHERE owner_id = $1
+        WHERE owner_id = $1 AND ($2 = '' OR language = $2)
         ORDER BY created_at DESC
-        OFFSET $2 LIMIT $3
-    `, userID, offset, limit)
+        OFFSET $3 LIMIT $4
+    `, userID, language, offset, limit)
 	if err != nil {
 		return nil, err
 	}
@@ -56,7 +155,7 @@ func (r *repo) GetPosts(ctx context.Context, userID uuid.UUID, page int, limit i
 	var posts []*Post
 	for rows.Next() {
 		post := &Post{}
-		err := rows.Scan(&post.ID, &post.OwnerID, &post.Content, &post.Likes, &post.CreatedAt, &post.UpdatedAt)
+		err := rows.Scan(&post.ID, &post.OwnerID, &post.Content, &post.SpoilerText, &post.Likes, &post.Scope, &post.Language, &post.LanguageDetected, &post.CreatedAt, &post.UpdatedAt)
 		if err != nil {
 			return nil, err
 		}
@@ -71,14 +170,17 @@ func (r *repo) GetPosts(ctx context.Context, userID uuid.UUID, page int, limit i
 	return posts, nil
 }
 
-func (r *repo) DeletePost(ctx context.Context, postID, userID uuid.UUID) error {
-	_, err := r.conn.Exec(ctx, "DELETE FROM posts WHERE id = $1 AND owner_id = $2", postID, userID)
+func (r *repo) DeletePost(ctx context.Context, tx pgx.Tx, postID, userID uuid.UUID) error {
+	_, err := r.q(tx).Exec(ctx, "DELETE FROM posts WHERE id = $1 AND owner_id = $2", postID, userID)
 
 	return err
 }
 
+// GetPostsByUserIDs returns every matching post across userIDs, unfiltered
+// by scope — callers that serve more than one viewer (feeds, federation)
+// are responsible for applying their own visibility check to the result.
 func (r *repo) GetPostsByUserIDs(ctx context.Context, userIDs []uuid.UUID) ([]*Post, error) {
-	rows, err := r.conn.Query(ctx, "SELECT id, owner_id, content, likes, created_at, updated_at FROM posts WHERE owner_id = ANY($1)", userIDs)
+	rows, err := r.db.Query(ctx, "SELECT id, owner_id, content, spoiler_text, likes, scope, language, language_detected, created_at, updated_at FROM posts WHERE owner_id = ANY($1)", userIDs)
 	if err != nil {
 		return nil, err
 	}
@@ -87,7 +189,7 @@ func (r *repo) GetPostsByUserIDs(ctx context.Context, userIDs []uuid.UUID) ([]*P
 	var posts []*Post
 	for rows.Next() {
 		post := &Post{}
-		err := rows.Scan(&post.ID, &post.OwnerID, &post.Content, &post.Likes, &post.CreatedAt, &post.UpdatedAt)
+		err := rows.Scan(&post.ID, &post.OwnerID, &post.Content, &post.SpoilerText, &post.Likes, &post.Scope, &post.Language, &post.LanguageDetected, &post.CreatedAt, &post.UpdatedAt)
 		if err != nil {
 			return nil, err
 		}
@@ -98,15 +200,15 @@ func (r *repo) GetPostsByUserIDs(ctx context.Context, userIDs []uuid.UUID) ([]*P
 	return posts, nil
 }
 
-func (r *repo) AddLike(ctx context.Context, postID uuid.UUID) error {
-	_, err := r.conn.Exec(ctx, "UPDATE posts SET likes = likes + 1 WHERE id = $1", postID)
+func (r *repo) AddLike(ctx context.Context, tx pgx.Tx, postID uuid.UUID) error {
+	_, err := r.q(tx).Exec(ctx, "UPDATE posts SET likes = likes + 1 WHERE id = $1", postID)
 
 	return err
 }
 
 func (r *repo) Exists(ctx context.Context, postID uuid.UUID) (bool, error) {
 	var exists bool
-	err := r.conn.QueryRow(ctx, "SELECT EXISTS(SELECT 1 FROM posts WHERE id = $1)", postID).Scan(&exists)
+	err := r.db.QueryRow(ctx, "SELECT EXISTS(SELECT 1 FROM posts WHERE id = $1)", postID).Scan(&exists)
 
 	return exists, err
 }
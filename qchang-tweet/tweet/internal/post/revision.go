@@ -0,0 +1,17 @@
+package post
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PostRevision is a snapshot of a post's content immediately before an edit,
+// kept so the edit history and source endpoints can reconstruct what the
+// post used to say.
+type PostRevision struct {
+	ID       uuid.UUID
+	PostID   uuid.UUID
+	Content  string
+	EditedAt time.Time
+}
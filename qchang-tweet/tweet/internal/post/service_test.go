@@ -0,0 +1,289 @@
+package post_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/require"
+	"tweet.io/internal/events"
+	"tweet.io/internal/post"
+	"tweet.io/internal/post/comment"
+	"tweet.io/internal/post/like"
+)
+
+// fakeTx is a no-op stand-in for pgx.Tx, just enough to let service methods
+// that call repo.Begin directly (rather than repo.WithTx) run against a
+// fake repo without a real database underneath.
+type fakeTx struct{}
+
+func (fakeTx) Begin(ctx context.Context) (pgx.Tx, error) { return fakeTx{}, nil }
+func (fakeTx) Commit(ctx context.Context) error          { return nil }
+func (fakeTx) Rollback(ctx context.Context) error        { return nil }
+func (fakeTx) Conn() *pgx.Conn                           { return nil }
+func (fakeTx) LargeObjects() pgx.LargeObjects            { return pgx.LargeObjects{} }
+func (fakeTx) Prepare(ctx context.Context, name, sql string) (*pgconn.StatementDescription, error) {
+	return nil, nil
+}
+func (fakeTx) CopyFrom(ctx context.Context, tableName pgx.Identifier, columnNames []string, rowSrc pgx.CopyFromSource) (int64, error) {
+	return 0, nil
+}
+func (fakeTx) SendBatch(ctx context.Context, b *pgx.Batch) pgx.BatchResults { return nil }
+func (fakeTx) Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error) {
+	return pgconn.CommandTag{}, nil
+}
+func (fakeTx) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+	return nil, nil
+}
+func (fakeTx) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row { return nil }
+
+// fakeRepo is a minimal in-memory stand-in for post's repository interface,
+// just enough to drive the service methods under test.
+type fakeRepo struct {
+	posts map[uuid.UUID]*post.Post
+}
+
+func newFakeRepo(posts ...*post.Post) *fakeRepo {
+	r := &fakeRepo{posts: make(map[uuid.UUID]*post.Post)}
+	for _, p := range posts {
+		r.posts[p.ID] = p
+	}
+
+	return r
+}
+
+func (r *fakeRepo) Begin(ctx context.Context) (pgx.Tx, error) { return fakeTx{}, nil }
+
+func (r *fakeRepo) WithTx(ctx context.Context, fn func(tx pgx.Tx) error) error {
+	return fn(fakeTx{})
+}
+
+func (r *fakeRepo) CreatePost(ctx context.Context, tx pgx.Tx, p *post.Post) error {
+	r.posts[p.ID] = p
+	return nil
+}
+
+func (r *fakeRepo) DeletePost(ctx context.Context, tx pgx.Tx, postID, userID uuid.UUID) error {
+	delete(r.posts, postID)
+	return nil
+}
+
+func (r *fakeRepo) GetPostsByUserIDs(ctx context.Context, userIDs []uuid.UUID) ([]*post.Post, error) {
+	return nil, nil
+}
+
+func (r *fakeRepo) AddLike(ctx context.Context, tx pgx.Tx, postID uuid.UUID) error { return nil }
+
+func (r *fakeRepo) Exists(ctx context.Context, postID uuid.UUID) (bool, error) {
+	_, ok := r.posts[postID]
+	return ok, nil
+}
+
+func (r *fakeRepo) AddRevision(ctx context.Context, tx pgx.Tx, postID uuid.UUID, content string) error {
+	return nil
+}
+
+func (r *fakeRepo) GetRevisions(ctx context.Context, postID uuid.UUID) ([]*post.PostRevision, error) {
+	return nil, nil
+}
+
+func (r *fakeRepo) UpdatePost(ctx context.Context, tx pgx.Tx, postID, userID uuid.UUID, content, spoilerText, scope, language string, languageDetected bool) error {
+	return nil
+}
+
+func (r *fakeRepo) GetPost(ctx context.Context, postID, userID uuid.UUID) (*post.Post, error) {
+	return r.GetByID(ctx, postID)
+}
+
+func (r *fakeRepo) GetByID(ctx context.Context, id uuid.UUID) (*post.Post, error) {
+	p, ok := r.posts[id]
+	if !ok {
+		return nil, pgx.ErrNoRows
+	}
+
+	return p, nil
+}
+
+func (r *fakeRepo) GetPosts(ctx context.Context, userID uuid.UUID, language string, page, limit int) ([]*post.Post, error) {
+	return nil, nil
+}
+
+// fakeCommentRepo is a minimal stand-in for commentRepository.
+type fakeCommentRepo struct{}
+
+func (r *fakeCommentRepo) Create(ctx context.Context, tx pgx.Tx, c *comment.Comment) error {
+	return nil
+}
+
+func (r *fakeCommentRepo) Update(ctx context.Context, tx pgx.Tx, id, postID, ownerID uuid.UUID, content, spoilerText, language string, languageDetected bool) error {
+	return nil
+}
+
+func (r *fakeCommentRepo) GetComment(ctx context.Context, id, postID, ownerID uuid.UUID) (*comment.Comment, error) {
+	return nil, pgx.ErrNoRows
+}
+
+func (r *fakeCommentRepo) GetByID(ctx context.Context, id uuid.UUID) (*comment.Comment, error) {
+	return nil, pgx.ErrNoRows
+}
+
+func (r *fakeCommentRepo) GetComments(ctx context.Context, postID, ownerID uuid.UUID, page, limit int) ([]*comment.Comment, error) {
+	return nil, nil
+}
+
+func (r *fakeCommentRepo) GetCommentsThreaded(ctx context.Context, postID uuid.UUID, page, limit int) ([]*comment.Comment, error) {
+	return nil, nil
+}
+
+func (r *fakeCommentRepo) GetReplies(ctx context.Context, postID, parentID uuid.UUID, page, limit int) ([]*comment.Comment, error) {
+	return nil, nil
+}
+
+func (r *fakeCommentRepo) Delete(ctx context.Context, tx pgx.Tx, id, postID, ownerID uuid.UUID) error {
+	return nil
+}
+
+func (r *fakeCommentRepo) AddRevision(ctx context.Context, tx pgx.Tx, commentID uuid.UUID, content string) error {
+	return nil
+}
+
+func (r *fakeCommentRepo) GetRevisions(ctx context.Context, commentID uuid.UUID) ([]*comment.Revision, error) {
+	return nil, nil
+}
+
+// fakeLikeRepo is a minimal stand-in for likeRepository.
+type fakeLikeRepo struct {
+	exists bool
+}
+
+func (r *fakeLikeRepo) Create(ctx context.Context, tx pgx.Tx, l *like.Like) error { return nil }
+
+func (r *fakeLikeRepo) Exists(ctx context.Context, postID, ownerID uuid.UUID) (bool, error) {
+	return r.exists, nil
+}
+
+// fakeOutbox records every event it's asked to enqueue, so tests can assert
+// on who ends up as OwnerID without a real outbox/Postgres behind it.
+type fakeOutbox struct {
+	events []events.Event
+}
+
+func (o *fakeOutbox) Enqueue(ctx context.Context, tx pgx.Tx, event events.Event) error {
+	o.events = append(o.events, event)
+	return nil
+}
+
+// fakeTagIndexer is a no-op stand-in for tagIndexer.
+type fakeTagIndexer struct{}
+
+func (fakeTagIndexer) IndexPost(ctx context.Context, tx pgx.Tx, postID uuid.UUID, content string) ([]string, error) {
+	return nil, nil
+}
+
+func (fakeTagIndexer) ReindexPost(ctx context.Context, tx pgx.Tx, postID uuid.UUID, content string) ([]string, error) {
+	return nil, nil
+}
+
+func (fakeTagIndexer) IndexComment(ctx context.Context, tx pgx.Tx, commentID uuid.UUID, content string) ([]string, error) {
+	return nil, nil
+}
+
+func (fakeTagIndexer) ReindexComment(ctx context.Context, tx pgx.Tx, commentID uuid.UUID, content string) ([]string, error) {
+	return nil, nil
+}
+
+// fakeFollowChecker is a stand-in for followChecker whose answer is fixed
+// per test.
+type fakeFollowChecker struct {
+	following bool
+}
+
+func (f fakeFollowChecker) IsFollowing(ctx context.Context, followerID, followeeID uuid.UUID) (bool, error) {
+	return f.following, nil
+}
+
+func newTestService(repo *fakeRepo, outbox *fakeOutbox, follows fakeFollowChecker) *post.Service {
+	return post.NewService(repo, &fakeCommentRepo{}, &fakeLikeRepo{}, outbox, fakeTagIndexer{}, follows)
+}
+
+func TestGetPost_ScopeEnforcement(t *testing.T) {
+	t.Parallel()
+
+	owner := uuid.New()
+	follower := uuid.New()
+	stranger := uuid.New()
+
+	tests := []struct {
+		name      string
+		scope     string
+		viewerID  uuid.UUID
+		following bool
+		wantErr   error
+	}{
+		{"owner sees own private post", post.ScopePrivate, owner, false, nil},
+		{"stranger blocked from private post", post.ScopePrivate, stranger, false, post.ErrPostNotFound},
+		{"anyone sees public post", post.ScopePublic, stranger, false, nil},
+		{"anyone sees unlisted post via direct link", post.ScopeUnlisted, stranger, false, nil},
+		{"follower sees followers-only post", post.ScopeFollowers, follower, true, nil},
+		{"non-follower blocked from followers-only post", post.ScopeFollowers, stranger, false, post.ErrPostNotFound},
+		{"anonymous viewer blocked from followers-only post", post.ScopeFollowers, uuid.Nil, false, post.ErrPostNotFound},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			p := &post.Post{ID: uuid.New(), OwnerID: owner, Scope: tt.scope}
+			svc := newTestService(newFakeRepo(p), &fakeOutbox{}, fakeFollowChecker{following: tt.following})
+
+			_, err := svc.GetPost(context.Background(), p.ID, tt.viewerID)
+			require.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestCreateComment_NotifiesPostOwnerNotCommenter(t *testing.T) {
+	t.Parallel()
+
+	owner := uuid.New()
+	commenter := uuid.New()
+	p := &post.Post{ID: uuid.New(), OwnerID: owner, Scope: post.ScopePublic}
+
+	outbox := &fakeOutbox{}
+	svc := newTestService(newFakeRepo(p), outbox, fakeFollowChecker{})
+
+	_, err := svc.CreateComment(context.Background(), &post.CreateCommentServiceParams{
+		PostID:  p.ID,
+		OwnerID: commenter,
+		Content: "nice post",
+	})
+	require.NoError(t, err)
+
+	require.Len(t, outbox.events, 1)
+	evt := outbox.events[0]
+	require.Equal(t, events.CommentCreated, evt.Type)
+	require.Equal(t, owner, evt.OwnerID, "the post's author should be notified, not the commenter")
+	require.Equal(t, commenter, evt.ActorID)
+}
+
+func TestAddLike_NotifiesPostOwnerNotLiker(t *testing.T) {
+	t.Parallel()
+
+	owner := uuid.New()
+	liker := uuid.New()
+	p := &post.Post{ID: uuid.New(), OwnerID: owner, Scope: post.ScopePublic}
+
+	outbox := &fakeOutbox{}
+	svc := newTestService(newFakeRepo(p), outbox, fakeFollowChecker{})
+
+	require.NoError(t, svc.AddLike(context.Background(), p.ID, liker))
+
+	require.Len(t, outbox.events, 1)
+	evt := outbox.events[0]
+	require.Equal(t, events.PostLiked, evt.Type)
+	require.Equal(t, owner, evt.OwnerID, "the post's author should be notified, not the liker")
+	require.Equal(t, liker, evt.ActorID)
+}
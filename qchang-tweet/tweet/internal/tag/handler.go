@@ -0,0 +1,127 @@
+package tag
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"tweet.io/internal/platform/reqctx"
+	"tweet.io/internal/platform/response"
+)
+
+const defaultTrendingLimit = 10
+
+type service interface {
+	GetTimeline(ctx context.Context, name, language string, viewerID uuid.UUID, page, limit int) ([]*PostSummary, error)
+	GetTrending(ctx context.Context, window time.Duration, limit int) ([]*TrendingTag, error)
+}
+
+type handler struct {
+	service service
+}
+
+func NewHandler(service service) *handler {
+	return &handler{service: service}
+}
+
+type PostSummaryResponse struct {
+	ID        string     `json:"id"`
+	OwnerID   string     `json:"owner_id"`
+	Content   string     `json:"content"`
+	Likes     int        `json:"likes"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt *time.Time `json:"updated_at"`
+}
+
+type GetTimelineResponse struct {
+	Posts []*PostSummaryResponse `json:"posts"`
+}
+
+type TrendingTagResponse struct {
+	Name      string `json:"name"`
+	PostCount int    `json:"post_count"`
+}
+
+type GetTrendingResponse struct {
+	Tags []*TrendingTagResponse `json:"tags"`
+}
+
+// GetTimeline returns a paginated timeline of posts bearing {tag}, across
+// all owners.
+func (h *handler) GetTimeline(w http.ResponseWriter, r *http.Request) {
+	name := mux.Vars(r)["tag"]
+
+	userID, ok := reqctx.UserIDFromContext(r.Context())
+	if !ok {
+		response.Error(w, http.StatusInternalServerError, errors.New("user not found"))
+		return
+	}
+
+	page := 1
+	if p, err := strconv.Atoi(r.URL.Query().Get("page")); err == nil && p > 0 {
+		page = p
+	}
+
+	limit := 10
+	if l, err := strconv.Atoi(r.URL.Query().Get("limit")); err == nil && l > 0 {
+		limit = l
+	}
+
+	language := r.URL.Query().Get("language")
+
+	posts, err := h.service.GetTimeline(r.Context(), name, language, userID, page, limit)
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, err)
+		return
+	}
+
+	resp := &GetTimelineResponse{}
+	for _, p := range posts {
+		resp.Posts = append(resp.Posts, &PostSummaryResponse{
+			ID:        p.ID.String(),
+			OwnerID:   p.OwnerID.String(),
+			Content:   p.Content,
+			Likes:     p.Likes,
+			CreatedAt: p.CreatedAt,
+			UpdatedAt: p.UpdatedAt,
+		})
+	}
+
+	response.Success(w, http.StatusOK, resp)
+}
+
+// GetTrending returns the top tags by post count within ?window (e.g.
+// "24h"), defaulting to 24 hours.
+func (h *handler) GetTrending(w http.ResponseWriter, r *http.Request) {
+	window := 24 * time.Hour
+	if windowParam := r.URL.Query().Get("window"); windowParam != "" {
+		parsed, err := time.ParseDuration(windowParam)
+		if err != nil {
+			response.Error(w, http.StatusBadRequest, err)
+			return
+		}
+		window = parsed
+	}
+
+	limit := defaultTrendingLimit
+	if l, err := strconv.Atoi(r.URL.Query().Get("limit")); err == nil && l > 0 {
+		limit = l
+	}
+
+	tags, err := h.service.GetTrending(r.Context(), window, limit)
+	if err != nil {
+		response.Error(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	resp := &GetTrendingResponse{}
+	for _, t := range tags {
+		resp.Tags = append(resp.Tags, &TrendingTagResponse{Name: t.Name, PostCount: t.PostCount})
+	}
+
+	response.Success(w, http.StatusOK, resp)
+}
@@ -0,0 +1,217 @@
+package tag
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PostSummary is a post as seen through a tag timeline. It's a type of its
+// own here so the tag package doesn't need to import post, which is what
+// calls into tag (through its tagIndexer interface) to index a post's
+// hashtags.
+type PostSummary struct {
+	ID      uuid.UUID
+	OwnerID uuid.UUID
+	Content string
+	Likes   int
+	// Scope is the owning post's visibility, used by Service.GetTimeline to
+	// hide followers-only posts from viewers who don't follow the owner.
+	Scope     string
+	CreatedAt time.Time
+	UpdatedAt *time.Time
+}
+
+type TrendingTag struct {
+	Name      string
+	PostCount int
+}
+
+// querier is satisfied by both *pgxpool.Pool and pgx.Tx, letting a write
+// method run either against the pool or inside a transaction passed in by
+// the service layer.
+type querier interface {
+	Exec(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row
+}
+
+type repo struct {
+	db *pgxpool.Pool
+}
+
+func NewRepository(db *pgxpool.Pool) *repo {
+	return &repo{db: db}
+}
+
+// WithTx runs fn inside a transaction acquired from the pool, committing on
+// success and rolling back on any error fn returns.
+func (r *repo) WithTx(ctx context.Context, fn func(tx pgx.Tx) error) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *repo) q(tx pgx.Tx) querier {
+	if tx != nil {
+		return tx
+	}
+
+	return r.db
+}
+
+func (r *repo) upsertTag(ctx context.Context, tx pgx.Tx, name string) (uuid.UUID, error) {
+	var id uuid.UUID
+	err := r.q(tx).QueryRow(ctx, `
+        INSERT INTO tags (id, name) VALUES ($1, $2)
+        ON CONFLICT (name) DO UPDATE SET name = EXCLUDED.name
+        RETURNING id
+    `, uuid.New(), name).Scan(&id)
+
+	return id, err
+}
+
+// TagPost links postID to each of tags within tx, upserting any tag not
+// seen before, so indexing commits atomically with the post write.
+func (r *repo) TagPost(ctx context.Context, tx pgx.Tx, postID uuid.UUID, tags []string) error {
+	for _, name := range tags {
+		tagID, err := r.upsertTag(ctx, tx, name)
+		if err != nil {
+			return err
+		}
+
+		_, err = r.q(tx).Exec(ctx, "INSERT INTO post_tags (post_id, tag_id) VALUES ($1, $2) ON CONFLICT DO NOTHING", postID, tagID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// RetagPost replaces postID's tag links with tags, so an edit that drops or
+// adds hashtags keeps the index in sync with the post's current content.
+func (r *repo) RetagPost(ctx context.Context, tx pgx.Tx, postID uuid.UUID, tags []string) error {
+	if _, err := r.q(tx).Exec(ctx, "DELETE FROM post_tags WHERE post_id = $1", postID); err != nil {
+		return err
+	}
+
+	return r.TagPost(ctx, tx, postID, tags)
+}
+
+// TagComment links commentID to each of tags within tx.
+func (r *repo) TagComment(ctx context.Context, tx pgx.Tx, commentID uuid.UUID, tags []string) error {
+	for _, name := range tags {
+		tagID, err := r.upsertTag(ctx, tx, name)
+		if err != nil {
+			return err
+		}
+
+		_, err = r.q(tx).Exec(ctx, "INSERT INTO comment_tags (comment_id, tag_id) VALUES ($1, $2) ON CONFLICT DO NOTHING", commentID, tagID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// RetagComment replaces commentID's tag links with tags.
+func (r *repo) RetagComment(ctx context.Context, tx pgx.Tx, commentID uuid.UUID, tags []string) error {
+	if _, err := r.q(tx).Exec(ctx, "DELETE FROM comment_tags WHERE comment_id = $1", commentID); err != nil {
+		return err
+	}
+
+	return r.TagComment(ctx, tx, commentID, tags)
+}
+
+// GetTimeline returns posts bearing tag name, most recent first, across all
+// owners — unlike post.GetPosts this is not scoped to a single owner_id.
+// language optionally restricts the timeline to posts tagged with that
+// language (empty matches any language). Private and unlisted posts are
+// never surfaced on a tag timeline; followers-only posts are returned here
+// and filtered by Service.GetTimeline, which is the one that knows whether
+// the viewer actually follows the owner.
+func (r *repo) GetTimeline(ctx context.Context, name, language string, page, limit int) ([]*PostSummary, error) {
+	offset := (page - 1) * limit
+	rows, err := r.db.Query(ctx, `
+        SELECT p.id, p.owner_id, p.content, p.likes, p.scope, p.created_at, p.updated_at
+        FROM posts p
+        JOIN post_tags pt ON pt.post_id = p.id
+        JOIN tags t ON t.id = pt.tag_id
+        WHERE t.name = $1 AND ($2 = '' OR p.language = $2) AND p.scope IN ('public', 'followers')
+        ORDER BY p.created_at DESC
+        OFFSET $3 LIMIT $4
+    `, name, language, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var posts []*PostSummary
+	for rows.Next() {
+		p := &PostSummary{}
+		if err := rows.Scan(&p.ID, &p.OwnerID, &p.Content, &p.Likes, &p.Scope, &p.CreatedAt, &p.UpdatedAt); err != nil {
+			return nil, err
+		}
+
+		posts = append(posts, p)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return posts, nil
+}
+
+// GetTrending returns the top limit tags by post count within the last
+// window, most-posted first. Only public posts count toward a trend, since
+// trending is an aggregate with no per-viewer context to check a follow or
+// private-post ownership against.
+func (r *repo) GetTrending(ctx context.Context, window time.Duration, limit int) ([]*TrendingTag, error) {
+	since := time.Now().Add(-window)
+
+	rows, err := r.db.Query(ctx, `
+        SELECT t.name, COUNT(*) AS post_count
+        FROM tags t
+        JOIN post_tags pt ON pt.tag_id = t.id
+        JOIN posts p ON p.id = pt.post_id
+        WHERE p.created_at >= $1 AND p.scope = 'public'
+        GROUP BY t.name
+        ORDER BY post_count DESC
+        LIMIT $2
+    `, since, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tags []*TrendingTag
+	for rows.Next() {
+		t := &TrendingTag{}
+		if err := rows.Scan(&t.Name, &t.PostCount); err != nil {
+			return nil, err
+		}
+
+		tags = append(tags, t)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return tags, nil
+}
@@ -0,0 +1,124 @@
+package tag
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+var ErrInvalidTag = errors.New("tag: name is empty after normalization")
+
+// scopeFollowers mirrors post.ScopeFollowers. It's duplicated rather than
+// imported so that tag does not depend on post; post in turn reaches tag
+// only through its tagIndexer interface.
+const scopeFollowers = "followers"
+
+type repository interface {
+	TagPost(ctx context.Context, tx pgx.Tx, postID uuid.UUID, tags []string) error
+	RetagPost(ctx context.Context, tx pgx.Tx, postID uuid.UUID, tags []string) error
+	TagComment(ctx context.Context, tx pgx.Tx, commentID uuid.UUID, tags []string) error
+	RetagComment(ctx context.Context, tx pgx.Tx, commentID uuid.UUID, tags []string) error
+	GetTimeline(ctx context.Context, name, language string, page, limit int) ([]*PostSummary, error)
+	GetTrending(ctx context.Context, window time.Duration, limit int) ([]*TrendingTag, error)
+}
+
+// followChecker reports whether one user follows another. It's used to hide
+// followers-only posts from a tag timeline when the viewer doesn't follow
+// the owner. Satisfied by follow.Service; mirrors post.followChecker and is
+// duplicated here for the same reason scopeFollowers is.
+type followChecker interface {
+	IsFollowing(ctx context.Context, followerID, followeeID uuid.UUID) (bool, error)
+}
+
+type Service struct {
+	repo    repository
+	follows followChecker
+}
+
+func NewService(repo repository, follows followChecker) *Service {
+	return &Service{repo: repo, follows: follows}
+}
+
+// IndexPost extracts hashtags from content and links them to postID within
+// tx, so the tag index commits atomically with the post write.
+func (s *Service) IndexPost(ctx context.Context, tx pgx.Tx, postID uuid.UUID, content string) ([]string, error) {
+	tags := Extract(content)
+	if err := s.repo.TagPost(ctx, tx, postID, tags); err != nil {
+		return nil, err
+	}
+
+	return tags, nil
+}
+
+// ReindexPost replaces postID's tag links to match content's current
+// hashtags, for use after an edit.
+func (s *Service) ReindexPost(ctx context.Context, tx pgx.Tx, postID uuid.UUID, content string) ([]string, error) {
+	tags := Extract(content)
+	if err := s.repo.RetagPost(ctx, tx, postID, tags); err != nil {
+		return nil, err
+	}
+
+	return tags, nil
+}
+
+// IndexComment extracts hashtags from content and links them to commentID
+// within tx.
+func (s *Service) IndexComment(ctx context.Context, tx pgx.Tx, commentID uuid.UUID, content string) ([]string, error) {
+	tags := Extract(content)
+	if err := s.repo.TagComment(ctx, tx, commentID, tags); err != nil {
+		return nil, err
+	}
+
+	return tags, nil
+}
+
+// ReindexComment replaces commentID's tag links to match content's current
+// hashtags, for use after an edit.
+func (s *Service) ReindexComment(ctx context.Context, tx pgx.Tx, commentID uuid.UUID, content string) ([]string, error) {
+	tags := Extract(content)
+	if err := s.repo.RetagComment(ctx, tx, commentID, tags); err != nil {
+		return nil, err
+	}
+
+	return tags, nil
+}
+
+// GetTimeline returns posts bearing name that viewerID is allowed to see:
+// public and followers-only posts the viewer actually follows the owner of.
+// Private and unlisted posts never reach the repo's result set in the first
+// place (see repo.GetTimeline).
+func (s *Service) GetTimeline(ctx context.Context, name, language string, viewerID uuid.UUID, page, limit int) ([]*PostSummary, error) {
+	name = normalize(name)
+	if name == "" {
+		return nil, ErrInvalidTag
+	}
+
+	posts, err := s.repo.GetTimeline(ctx, name, language, page, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	visible := make([]*PostSummary, 0, len(posts))
+	for _, p := range posts {
+		if p.Scope == scopeFollowers {
+			following, err := s.follows.IsFollowing(ctx, viewerID, p.OwnerID)
+			if err != nil {
+				return nil, err
+			}
+			if !following {
+				continue
+			}
+		}
+
+		visible = append(visible, p)
+	}
+
+	return visible, nil
+}
+
+func (s *Service) GetTrending(ctx context.Context, window time.Duration, limit int) ([]*TrendingTag, error) {
+	return s.repo.GetTrending(ctx, window, limit)
+}
@@ -0,0 +1,39 @@
+// Package tag extracts #hashtag tokens from post and comment content and
+// indexes them so posts can be browsed by tag.
+package tag
+
+import (
+	"regexp"
+	"strings"
+)
+
+var hashtagPattern = regexp.MustCompile(`#[\p{L}\p{N}_]+`)
+
+// Extract returns the normalized, deduplicated hashtags found in content, in
+// first-seen order, lowercased and stripped of the leading '#'.
+func Extract(content string) []string {
+	matches := hashtagPattern.FindAllString(content, -1)
+	if len(matches) == 0 {
+		return nil
+	}
+
+	seen := make(map[string]bool, len(matches))
+	var tags []string
+	for _, m := range matches {
+		name := strings.ToLower(strings.TrimPrefix(m, "#"))
+		if name == "" || seen[name] {
+			continue
+		}
+
+		seen[name] = true
+		tags = append(tags, name)
+	}
+
+	return tags
+}
+
+// normalize applies the same lowercasing used during extraction to a tag
+// name looked up by a caller, so "#Go" and "go" resolve to the same timeline.
+func normalize(name string) string {
+	return strings.ToLower(strings.TrimPrefix(strings.TrimSpace(name), "#"))
+}
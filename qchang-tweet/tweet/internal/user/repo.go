@@ -6,6 +6,7 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 var (
@@ -13,23 +14,41 @@ var (
 )
 
 type repo struct {
-	conn *pgx.Conn
+	db *pgxpool.Pool
 }
 
-func NewRepository(conn *pgx.Conn) *repo {
-	return &repo{conn: conn}
+func NewRepository(db *pgxpool.Pool) *repo {
+	return &repo{db: db}
+}
+
+// WithTx runs fn inside a transaction acquired from the pool, committing on
+// success and rolling back on any error fn returns.
+func (r *repo) WithTx(ctx context.Context, fn func(tx pgx.Tx) error) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
 }
 
 func (r *repo) CreateUser(ctx context.Context, user *User) error {
-	_, err := r.conn.Exec(ctx, "INSERT INTO users (id, email, firstname, lastname, displayname, hashed_password, created_at) VALUES ($1, $2, $3, $4, $5, $6, $7)",
-		user.ID, user.Email, user.Firstname, user.Lastname, user.Displayname, user.HashedPassword, user.CreatedAt)
+	_, err := r.db.Exec(ctx, `
+        INSERT INTO users (id, email, firstname, lastname, displayname, hashed_password, public_key_pem, private_key_pem, created_at)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+    `, user.ID, user.Email, user.Firstname, user.Lastname, user.Displayname, user.HashedPassword, user.PublicKeyPEM, user.PrivateKeyPEM, user.CreatedAt)
 
 	return err
 }
 
 func (r *repo) FindByEmail(ctx context.Context, email string) (*User, error) {
 	user := &User{}
-	err := r.conn.QueryRow(ctx, "SELECT id, email, hashed_password FROM users WHERE email = $1", email).Scan(&user.ID, &user.Email, &user.HashedPassword)
+	err := r.db.QueryRow(ctx, "SELECT id, email, hashed_password FROM users WHERE email = $1", email).Scan(&user.ID, &user.Email, &user.HashedPassword)
 
 	if err == pgx.ErrNoRows {
 		return nil, ErrNotFound
@@ -40,7 +59,7 @@ func (r *repo) FindByEmail(ctx context.Context, email string) (*User, error) {
 
 func (r *repo) FindByID(ctx context.Context, id uuid.UUID) (*User, error) {
 	user := &User{}
-	err := r.conn.QueryRow(ctx, "SELECT id, email, firstname, lastname, displayname, created_at, updated_at FROM users WHERE id = $1", id).
+	err := r.db.QueryRow(ctx, "SELECT id, email, firstname, lastname, displayname, created_at, updated_at FROM users WHERE id = $1", id).
 		Scan(&user.ID, &user.Email, &user.Firstname, &user.Lastname, &user.Displayname, &user.CreatedAt, &user.UpdatedAt)
 
 	if err == pgx.ErrNoRows {
@@ -49,3 +68,18 @@ func (r *repo) FindByID(ctx context.Context, id uuid.UUID) (*User, error) {
 
 	return user, err
 }
+
+func (r *repo) FindByDisplayname(ctx context.Context, displayname string) (*User, error) {
+	user := &User{}
+	err := r.db.QueryRow(ctx, `
+        SELECT id, email, firstname, lastname, displayname, public_key_pem, created_at, updated_at
+        FROM users WHERE displayname = $1
+    `, displayname).
+		Scan(&user.ID, &user.Email, &user.Firstname, &user.Lastname, &user.Displayname, &user.PublicKeyPEM, &user.CreatedAt, &user.UpdatedAt)
+
+	if err == pgx.ErrNoRows {
+		return nil, ErrNotFound
+	}
+
+	return user, err
+}
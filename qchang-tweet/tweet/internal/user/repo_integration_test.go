@@ -0,0 +1,62 @@
+//go:build integration
+
+package user_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"tweet.io/internal/platform/dbtest"
+	"tweet.io/internal/user"
+)
+
+const usersSchemaSQL = `
+CREATE TABLE IF NOT EXISTS users (
+    id UUID PRIMARY KEY,
+    email TEXT UNIQUE NOT NULL,
+    firstname TEXT NOT NULL,
+    lastname TEXT NOT NULL,
+    displayname TEXT NOT NULL,
+    hashed_password BYTEA NOT NULL,
+    public_key_pem TEXT NOT NULL,
+    private_key_pem TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ
+);
+`
+
+// TestRepo_CreateUser_FindByEmail exercises the repo against a real
+// Postgres instance (run with `go test -tags=integration ./...` and
+// TEST_DATABASE_URL set), since pgx's query/scan wiring isn't caught by
+// anything that mocks the pool.
+func TestRepo_CreateUser_FindByEmail(t *testing.T) {
+	t.Parallel()
+
+	pool := dbtest.Pool(t)
+	ctx := context.Background()
+
+	_, err := pool.Exec(ctx, usersSchemaSQL)
+	require.NoError(t, err)
+
+	repo := user.NewRepository(pool)
+
+	u, err := user.NewUser("integration-test@example.com", "hunter22222", "Ada", "Lovelace", "ada")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		pool.Exec(context.Background(), "DELETE FROM users WHERE id = $1", u.ID)
+	})
+
+	require.NoError(t, repo.CreateUser(ctx, u))
+
+	found, err := repo.FindByEmail(ctx, u.Email)
+	require.NoError(t, err)
+	require.Equal(t, u.ID, found.ID)
+
+	byID, err := repo.FindByID(ctx, u.ID)
+	require.NoError(t, err)
+	require.Equal(t, u.Displayname, byID.Displayname)
+
+	_, err = repo.FindByEmail(ctx, "does-not-exist@example.com")
+	require.ErrorIs(t, err, user.ErrNotFound)
+}
@@ -0,0 +1,107 @@
+package user
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+)
+
+var ErrEmailAlreadyExists = errors.New("email already exists")
+
+type repository interface {
+	CreateUser(ctx context.Context, user *User) error
+	FindByEmail(ctx context.Context, email string) (*User, error)
+	FindByID(ctx context.Context, id uuid.UUID) (*User, error)
+	FindByDisplayname(ctx context.Context, displayname string) (*User, error)
+}
+
+type CreateServiceParams struct {
+	Email       string
+	Password    string
+	Firstname   string
+	Lastname    string
+	Displayname string
+}
+
+type Service struct {
+	repo repository
+}
+
+func NewService(repo repository) *Service {
+	return &Service{repo: repo}
+}
+
+func (s *Service) CreateUser(ctx context.Context, params *CreateServiceParams) (*User, error) {
+	existing, err := s.repo.FindByEmail(ctx, params.Email)
+	if err != nil && err != ErrNotFound {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, ErrEmailAlreadyExists
+	}
+
+	user, err := NewUser(params.Email, params.Password, params.Firstname, params.Lastname, params.Displayname)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.CreateUser(ctx, user); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+type ProvisionExternalUserParams struct {
+	Email       string
+	Firstname   string
+	Lastname    string
+	Displayname string
+}
+
+// ProvisionExternalUser finds or creates the User for someone an external
+// auth source (LDAP, OAuth2) just authenticated. It mirrors CreateUser's
+// dedupe-by-email and persist flow but never takes a password, since
+// authentication for this user keeps going through the external source.
+func (s *Service) ProvisionExternalUser(ctx context.Context, params *ProvisionExternalUserParams) (*User, error) {
+	existing, err := s.repo.FindByEmail(ctx, params.Email)
+	if err != nil && err != ErrNotFound {
+		return nil, err
+	}
+	if existing != nil {
+		return existing, nil
+	}
+
+	user, err := NewExternalUser(params.Email, params.Firstname, params.Lastname, params.Displayname)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.CreateUser(ctx, user); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+func (s *Service) Authenticate(ctx context.Context, email string, password string) (*User, error) {
+	user, err := s.repo.FindByEmail(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+
+	if !user.CheckPassword(password) {
+		return nil, ErrInvalidPassword
+	}
+
+	return user, nil
+}
+
+func (s *Service) GetUser(ctx context.Context, id uuid.UUID) (*User, error) {
+	return s.repo.FindByID(ctx, id)
+}
+
+func (s *Service) GetUserByDisplayname(ctx context.Context, displayname string) (*User, error) {
+	return s.repo.FindByDisplayname(ctx, displayname)
+}
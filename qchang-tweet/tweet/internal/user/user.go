@@ -1,6 +1,10 @@
 package user
 
 import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
 	"errors"
 	"regexp"
 	"time"
@@ -9,6 +13,8 @@ import (
 	"golang.org/x/crypto/bcrypt"
 )
 
+const rsaKeyBits = 2048
+
 var (
 	ErrInvalidEmail        = errors.New("invalid email")
 	ErrInvalidPassword     = errors.New("invalid password")
@@ -24,8 +30,13 @@ type User struct {
 	Lastname       string
 	Displayname    string
 	HashedPassword []byte
-	CreatedAt      time.Time
-	UpdatedAt      *time.Time
+	// PublicKeyPEM/PrivateKeyPEM are the user's ActivityPub signing
+	// keypair, generated once on creation so the user can act as a
+	// federated actor without a separate provisioning step.
+	PublicKeyPEM  string
+	PrivateKeyPEM string
+	CreatedAt     time.Time
+	UpdatedAt     *time.Time
 }
 
 func NewUser(email string, password string, firstname string, lastname string, displayname string) (*User, error) {
@@ -58,6 +69,53 @@ func NewUser(email string, password string, firstname string, lastname string, d
 	if err != nil {
 		return nil, err
 	}
+
+	if err := user.generateKeyPair(); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// NewExternalUser creates a User provisioned by an external auth source
+// (LDAP, OAuth2, ...) rather than local signup. Its HashedPassword is set
+// to a random value no plaintext password will ever match, since the
+// external source remains the system of record for this user's
+// credentials and local password login should never succeed for it.
+func NewExternalUser(email, firstname, lastname, displayname string) (*User, error) {
+	if !isValidEmail(email) {
+		return nil, ErrInvalidEmail
+	}
+
+	if firstname == "" {
+		return nil, ErrRequiredFirstname
+	}
+
+	if lastname == "" {
+		return nil, ErrRequiredLastname
+	}
+
+	if displayname == "" {
+		return nil, ErrRequiredDisplayname
+	}
+
+	user := &User{
+		ID:          uuid.New(),
+		Email:       email,
+		Firstname:   firstname,
+		Lastname:    lastname,
+		Displayname: displayname,
+		CreatedAt:   time.Now(),
+	}
+
+	if err := user.setUnusablePassword(); err != nil {
+		return nil, err
+	}
+
+	if err := user.generateKeyPair(); err != nil {
+		return nil, err
+	}
+
 	return user, nil
 }
 
@@ -75,11 +133,53 @@ func (u *User) setPassword(password string) error {
 	return nil
 }
 
+// setUnusablePassword hashes random bytes instead of a chosen password, so
+// CheckPassword can never succeed against it.
+func (u *User) setUnusablePassword() error {
+	random := make([]byte, 32)
+	if _, err := rand.Read(random); err != nil {
+		return err
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword(random, bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+
+	u.HashedPassword = hashedPassword
+	return nil
+}
+
 func (u *User) CheckPassword(password string) bool {
 	err := bcrypt.CompareHashAndPassword(u.HashedPassword, []byte(password))
 	return err == nil
 }
 
+// generateKeyPair creates the RSA keypair used to sign and verify this
+// user's outbound and inbound ActivityPub deliveries.
+func (u *User) generateKeyPair() error {
+	key, err := rsa.GenerateKey(rand.Reader, rsaKeyBits)
+	if err != nil {
+		return err
+	}
+
+	publicKeyBytes, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		return err
+	}
+
+	u.PrivateKeyPEM = string(pem.EncodeToMemory(&pem.Block{
+		Type:  "RSA PRIVATE KEY",
+		Bytes: x509.MarshalPKCS1PrivateKey(key),
+	}))
+	u.PublicKeyPEM = string(pem.EncodeToMemory(&pem.Block{
+		Type:  "PUBLIC KEY",
+		Bytes: publicKeyBytes,
+	}))
+
+	return nil
+}
+
 func isValidEmail(email string) bool {
 	// This is a simple email validation regex, it may not cover all cases
 	emailRegex := regexp.MustCompile(`^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}$`)